@@ -0,0 +1,38 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "time"
+
+// ActiveRate calculates the internal rate of return of the difference between
+// actual and benchmark payments, which measures the return earned over the
+// benchmark.
+//
+// Payments are aligned by date, with the amounts on each date netted within
+// each series. A date present in only one series is treated as having a zero
+// amount in the other. The difference must contain both positive and negative
+// payments for the rate to be meaningful, otherwise ErrInvalidPayments is
+// returned.
+func ActiveRate(actual, benchmark []Payment) (float64, error) {
+	var dates []time.Time
+	diff := make(map[time.Time]float64)
+	add := func(payments []Payment, sign float64) {
+		for _, p := range payments {
+			date := p.Date.UTC()
+			if _, ok := diff[date]; !ok {
+				dates = append(dates, date)
+			}
+			diff[date] += sign * p.Amount
+		}
+	}
+	add(actual, 1)
+	add(benchmark, -1)
+
+	payments := make([]Payment, len(dates))
+	for i, d := range dates {
+		payments[i] = Payment{d, diff[d]}
+	}
+	return Compute(payments)
+}
@@ -0,0 +1,48 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestActiveRate(t *testing.T) {
+	// Both invest 1000, but the actual series redeems 100 more a year later
+	// and also receives an interim payment absent from the benchmark.
+	actual := []Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2016-12-01"), -50},
+		{parseDate("2017-06-11"), 1210},
+	}
+	benchmark := []Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2017-06-11"), 1100},
+	}
+
+	rate, err := ActiveRate(actual, benchmark)
+	if err != nil {
+		t.Fatal("Error computing active rate:", err)
+	}
+
+	expected, _ := Compute([]Payment{
+		{parseDate("2016-12-01"), -50},
+		{parseDate("2017-06-11"), 110},
+	})
+	if math.Abs(rate-expected) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, rate)
+	}
+}
+
+func TestActiveRateIdentical(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2017-06-11"), 1100},
+	}
+	_, err := ActiveRate(payments, payments)
+	if err != ErrInvalidPayments {
+		t.Errorf("Invalid error for identical series: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNoAliasing(t *testing.T) {
+	loaded, err := loadPayments("random.csv")
+	if err != nil {
+		t.Fatal("Error loading input:", err)
+	}
+
+	// payments has spare capacity holding sibling, so any append to it or
+	// write past its length would show up in sibling.
+	backing := append(loaded, Payment{parseDate("2001-01-01"), 12345})
+	payments := backing[:len(loaded)]
+	sibling := backing[len(loaded):]
+	want := append([]Payment(nil), backing...)
+
+	sorted := sortPayments(payments)
+	first, last := sorted[0].Date, sorted[len(sorted)-1].Date
+	mid := first.Add(last.Sub(first) / 2)
+	value := func(time.Time) float64 { return 1000 }
+
+	calls := map[string]func(){
+		"Compute": func() { Compute(payments) },
+		"ComputeWithOptions": func() {
+			ComputeWithOptions(payments, Options{AggregateSameDay: true, MidPeriodFlows: true})
+		},
+		"ComputeVerbose":           func() { ComputeVerbose(payments, Options{}) },
+		"Canonical":                func() { Canonical(payments, Options{AggregateSameDay: true}) },
+		"Offsets":                  func() { Offsets(payments) },
+		"Solve":                    func() { NewSolver(Options{}).Solve(payments) },
+		"ActiveRate":               func() { ActiveRate(payments, payments) },
+		"BinFlows":                 func() { BinFlows(payments, 365*24*time.Hour) },
+		"ComputeBounds":            func() { ComputeBounds(payments) },
+		"Classify":                 func() { Classify(payments, []float64{0.5}) },
+		"ComputeDecimalRate":       func() { ComputeDecimalRate(payments, 4) },
+		"DominantFlow":             func() { DominantFlow(payments) },
+		"MaxDrawdown":              func() { MaxDrawdown(0.1, payments) },
+		"ComputeWithOneTimeFee":    func() { ComputeWithOneTimeFee(payments, 10, mid) },
+		"ComputeWithContinuousFee": func() { ComputeWithContinuousFee(payments, 0.01) },
+		"BreakevenFee":             func() { BreakevenFee(payments, 0.1, ExitFee) },
+		"EquivalentFixedRate":      func() { EquivalentFixedRate(payments) },
+		"DecomposeTotalReturn": func() {
+			DecomposeTotalReturn(payments, payments, 1000, last)
+		},
+		"ForwardRate":            func() { ForwardRate(payments, mid, 1000) },
+		"ComputeWithGracePeriod": func() { ComputeWithGracePeriod(payments, mid, 0.01) },
+		"HarvestedRate": func() {
+			HarvestedRate(payments, []time.Time{mid}, 0.3, value)
+		},
+		"ComputeHedged":    func() { ComputeHedged(payments, 0.01) },
+		"MeetsHurdle":      func() { MeetsHurdle(payments, 0.1) },
+		"OutlierDiagnosis": func() { OutlierDiagnosis(payments, 1) },
+		"PeriodsToTarget": func() {
+			PeriodsToTarget(payments, -100, 365*24*time.Hour, 0.5, value)
+		},
+		"RebalanceImpact": func() { RebalanceImpact(payments, mid, 1000) },
+		"RollingRates": func() {
+			RollingRates(payments, 3*365*24*time.Hour, 365*24*time.Hour, value)
+		},
+		"SmoothedRate":    func() { SmoothedRate(payments, 1000) },
+		"ComputeAfterTax": func() { ComputeAfterTax(payments, 0.3) },
+		"AverageTransactionReturn": func() {
+			AverageTransactionReturn(payments, 1000, last.AddDate(1, 0, 0), func(Payment) float64 { return 0.1 })
+		},
+		"TrimmedRate": func() { TrimmedRate(payments, 2) },
+		"ComputeWithUncertainty": func() {
+			ComputeWithUncertainty(payments, make([]float64, len(payments)))
+		},
+		"ComputeReinvestDistributions": func() {
+			ComputeReinvestDistributions(payments, payments, true, 1000, last)
+		},
+		"ComputeLeveraged": func() {
+			ComputeLeveraged(payments, 1000, 0.05, first, last)
+		},
+		"BreakEvenTerminalValue": func() { BreakEvenTerminalValue(payments, last) },
+		"ComputePosterior":       func() { ComputePosterior(payments, 0.1, 0.05) },
+		"ComputeWithPerfFee": func() {
+			ComputeWithPerfFee(payments, payments, 0.01, 0.2)
+		},
+		"ScaleInvarianceCheck":       func() { ScaleInvarianceCheck(payments, 2) },
+		"ComputeWithFinancingCharge": func() { ComputeWithFinancingCharge(payments, 0.05) },
+		"FromBalances":               func() { FromBalances(payments) },
+		"RatePerspectives":           func() { RatePerspectives(payments) },
+		"ComputeFromPercents": func() {
+			ComputeFromPercents(1000, payments, 1000, last)
+		},
+		"ComputeIndexed": func() {
+			ComputeIndexed(payments, func(time.Time) float64 { return 100 }, first)
+		},
+		"ComputeCommitments": func() {
+			ComputeCommitments(payments, payments, 1000, last)
+		},
+		"PEMetrics":  func() { PEMetrics(payments, payments, 1000) },
+		"TargetDate": func() { TargetDate(payments, 1000, 0.1) },
+		"ComputeStream": func() {
+			in, out := make(chan []Payment, 1), make(chan Result, 1)
+			in <- payments
+			close(in)
+			go ComputeStream(context.Background(), in, out)
+			for range out {
+			}
+		},
+	}
+
+	for name, call := range calls {
+		call()
+		if !reflect.DeepEqual(backing, want) {
+			t.Errorf("%s modified the backing array of payments", name)
+			copy(backing, want)
+		}
+	}
+	if sibling[0] != want[len(loaded)] {
+		t.Errorf("Expected sibling %v, but was %v", want[len(loaded)], sibling[0])
+	}
+}
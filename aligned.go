@@ -0,0 +1,55 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"sort"
+	"time"
+)
+
+// ComputeAligned calculates the internal rate of return of several series of
+// payments made on the same dates. Each row of amountRows holds the amounts of
+// a series, corresponding to dates by position.
+//
+// The offsets of the dates are computed only once and shared by all the
+// series, which makes it faster than calling Compute for each of them. The
+// rate and the error of each series are returned at its position, with a
+// zero rate for series that could not be computed.
+func ComputeAligned(dates []time.Time, amountRows [][]float64) ([]float64, []error) {
+	order := make([]int, len(dates))
+	sorted := make([]Payment, len(dates))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return dates[order[i]].Before(dates[order[j]])
+	})
+	for i, j := range order {
+		sorted[i].Date = dates[j]
+	}
+	exps := newSeries(sorted).exps
+
+	rates := make([]float64, len(amountRows))
+	errs := make([]error, len(amountRows))
+	for r, amounts := range amountRows {
+		if len(amounts) != len(dates) {
+			errs[r] = ErrMismatchedLengths
+			continue
+		}
+
+		s := series{amounts: make([]float64, len(amounts)), exps: exps}
+		for i, j := range order {
+			sorted[i].Amount = amounts[j]
+			s.amounts[i] = amounts[j]
+		}
+		if errs[r] = validatePayments(sorted); errs[r] != nil {
+			continue
+		}
+
+		rates[r] = solve(s, Options{}).Rate
+	}
+
+	return rates, errs
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func alignedPanel(rows, cols int) ([]time.Time, [][]float64) {
+	rnd := rand.New(rand.NewSource(1))
+	start := parseDate("2016-06-11")
+
+	dates := make([]time.Time, cols)
+	for i := range dates {
+		dates[i] = start.Add(time.Duration(rnd.Intn(3650)) * 24 * time.Hour)
+	}
+	dates[cols-1] = start.Add(3651 * 24 * time.Hour)
+
+	amountRows := make([][]float64, rows)
+	for r := range amountRows {
+		amountRows[r] = make([]float64, cols)
+		total := 0.0
+		for i := 0; i < cols-1; i++ {
+			amountRows[r][i] = -rnd.Float64() * 1000
+			total -= amountRows[r][i]
+		}
+		amountRows[r][cols-1] = total * (1 + rnd.Float64())
+	}
+	return dates, amountRows
+}
+
+func TestComputeAligned(t *testing.T) {
+	dates, amountRows := alignedPanel(20, 50)
+	amountRows = append(amountRows, make([]float64, 50), make([]float64, 49))
+
+	rates, errs := ComputeAligned(dates, amountRows)
+	for r, amounts := range amountRows[:20] {
+		payments := make([]Payment, len(dates))
+		for i, d := range dates {
+			payments[i] = Payment{d, amounts[i]}
+		}
+
+		expected, err := Compute(payments)
+		if err != nil {
+			t.Fatal("Error computing XIRR:", err)
+		}
+		if errs[r] != nil || math.Abs(rates[r]-expected) >= maxError {
+			t.Errorf("Expected %.10f for row %d, but was %.10f (%v)", expected, r, rates[r], errs[r])
+		}
+	}
+
+	if errs[20] != ErrInvalidPayments {
+		t.Errorf("Invalid error for zero amounts: %v", errs[20])
+	}
+	if errs[21] != ErrMismatchedLengths {
+		t.Errorf("Invalid error for short row: %v", errs[21])
+	}
+}
+
+func BenchmarkComputeAligned(b *testing.B) {
+	dates, amountRows := alignedPanel(100, 500)
+	b.Run("Aligned", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ComputeAligned(dates, amountRows)
+		}
+	})
+	b.Run("Compute", func(b *testing.B) {
+		payments := make([]Payment, len(dates))
+		for i := 0; i < b.N; i++ {
+			for _, amounts := range amountRows {
+				for j, d := range dates {
+					payments[j] = Payment{d, amounts[j]}
+				}
+				Compute(payments)
+			}
+		}
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+// FromBalances derives the payments into an account from balances, the
+// running balance of the account on each date, like in a bank statement.
+//
+// The account is assumed to hold cash that earns nothing, so every change in
+// balance is a payment. The first balance is a payment made into an empty
+// account, and balances on the same date follow each other in the order
+// given. Payments into the account are negative and those out of it are
+// positive, as for any investment, and dates on which the balance does not
+// change have no payment. A final payment of the value of the account is to
+// be added before computing the internal rate of return. With the final
+// balance as that value, the rate is zero since the account earns nothing.
+// ErrInvalidPayments is returned when there are no balances.
+func FromBalances(balances []Payment) ([]Payment, error) {
+	if len(balances) == 0 {
+		return nil, ErrInvalidPayments
+	}
+
+	var payments []Payment
+	previous := 0.0
+	for _, b := range normalize(balances, Options{}) {
+		if b.Amount != previous {
+			payments = append(payments, Payment{b.Date, previous - b.Amount})
+		}
+		previous = b.Amount
+	}
+	return payments, nil
+}
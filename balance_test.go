@@ -0,0 +1,74 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestFromBalances(t *testing.T) {
+	payments, err := FromBalances([]Payment{
+		{parseDate("2021-01-01"), 1500},
+		{parseDate("2020-01-01"), 1000},
+		{parseDate("2020-07-01"), 1000},
+		{parseDate("2021-07-01"), 1200},
+	})
+	if err != nil {
+		t.Fatal("Error deriving payments:", err)
+	}
+
+	expected := []Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2021-01-01"), -500},
+		{parseDate("2021-07-01"), 300},
+	}
+	if !reflect.DeepEqual(payments, expected) {
+		t.Fatalf("Expected %v, but was %v", expected, payments)
+	}
+
+	rate, err := Compute(append(payments, Payment{parseDate("2022-01-01"), 1200}))
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(rate) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", 0.0, rate)
+	}
+}
+
+func TestFromBalancesEmpty(t *testing.T) {
+	if _, err := FromBalances(nil); err != ErrInvalidPayments {
+		t.Errorf("Expected %v, but was %v", ErrInvalidPayments, err)
+	}
+}
+
+func TestFromBalancesSameDate(t *testing.T) {
+	// Enough balances to be sorted by something other than insertion sort,
+	// with those on the same date listed in their order of posting.
+	var balances, expected []Payment
+	for i := 1; i <= 20; i++ {
+		date := parseDate("2020-01-01").AddDate(0, 0, i%2)
+		balances = append(balances, Payment{date, float64(100 * i)})
+	}
+	previous := 0.0
+	for _, odd := range []bool{false, true} {
+		for i := 1; i <= 20; i++ {
+			if (i%2 == 1) == odd {
+				date := parseDate("2020-01-01").AddDate(0, 0, i%2)
+				expected = append(expected, Payment{date, previous - float64(100*i)})
+				previous = float64(100 * i)
+			}
+		}
+	}
+
+	payments, err := FromBalances(balances)
+	if err != nil {
+		t.Fatal("Error deriving payments:", err)
+	}
+	if !reflect.DeepEqual(payments, expected) {
+		t.Errorf("Expected %v, but was %v", expected, payments)
+	}
+}
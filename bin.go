@@ -0,0 +1,41 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "time"
+
+// A Bin represents the net amount of payments made or received from Start,
+// inclusive, to End, exclusive.
+type Bin struct {
+	Start  time.Time
+	End    time.Time
+	Amount float64
+}
+
+// BinFlows groups payments into consecutive bins of binWidth, starting from
+// the earliest payment, and returns the net amount of each bin. Bins without
+// payments are included with a zero amount, and the last bin is the one
+// containing the latest payment.
+func BinFlows(payments []Payment, binWidth time.Duration) ([]Bin, error) {
+	if binWidth <= 0 {
+		return nil, ErrInvalidInterval
+	}
+	if len(payments) == 0 {
+		return nil, nil
+	}
+
+	sorted := normalize(payments, Options{})
+	start := sorted[0].Date
+	bins := []Bin{{start, start.Add(binWidth), 0}}
+	for _, p := range sorted {
+		for !p.Date.Before(bins[len(bins)-1].End) {
+			end := bins[len(bins)-1].End
+			bins = append(bins, Bin{end, end.Add(binWidth), 0})
+		}
+		bins[len(bins)-1].Amount += p.Amount
+	}
+
+	return bins, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBinFlows(t *testing.T) {
+	bins, err := BinFlows([]Payment{
+		{parseDate("2018-03-20"), 400},
+		{parseDate("2018-01-01"), -1000},
+		{parseDate("2018-01-15"), -500},
+		{parseDate("2018-02-05"), 250},
+		{parseDate("2018-01-31"), -100},
+		{parseDate("2018-03-30"), 1200},
+	}, 30*24*time.Hour)
+	if err != nil {
+		t.Fatal("Error binning payments:", err)
+	}
+
+	expected := []Bin{
+		{parseDate("2018-01-01"), parseDate("2018-01-31"), -1500},
+		{parseDate("2018-01-31"), parseDate("2018-03-02"), 150},
+		{parseDate("2018-03-02"), parseDate("2018-04-01"), 1600},
+	}
+	if len(bins) != len(expected) {
+		t.Fatalf("Expected %d bins, but was %d", len(expected), len(bins))
+	}
+	for i, b := range bins {
+		if !b.Start.Equal(expected[i].Start) || !b.End.Equal(expected[i].End) || b.Amount != expected[i].Amount {
+			t.Errorf("Expected bin %d to be %v, but was %v", i, expected[i], b)
+		}
+	}
+}
+
+func TestBinFlowsInvalidWidth(t *testing.T) {
+	_, err := BinFlows([]Payment{{parseDate("2018-01-01"), -1000}}, 0)
+	if err != ErrInvalidInterval {
+		t.Errorf("Invalid error for zero width: %v", err)
+	}
+}
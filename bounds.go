@@ -0,0 +1,95 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "math"
+
+const (
+	// epsilon is the unit roundoff of float64.
+	epsilon = 0x1p-53
+
+	// maxBoundWidth is the width beyond which ComputeBounds stops widening
+	// the bounds around the rate.
+	maxBoundWidth = 1.0
+)
+
+// ComputeBounds calculates bounds that an internal rate of return of payments
+// is guaranteed to lie within, despite rounding errors in floating point
+// arithmetic.
+//
+// Bounds are widened around the rate found by Compute, starting from a width
+// of the tolerance used by it, until the signs of the net present value at
+// both are certain after accounting for the worst-case rounding errors in
+// evaluating it, and are opposite. A rate lies between them since the net
+// present value is continuous. Unlike intervals from interval arithmetic, the
+// error bounds are derived from the number of operations and the magnitude of
+// each discounted payment, which also assumes math.Pow is accurate to a few
+// units in the last place. Bounds are NaN when the rate is, and
+// ErrNotConverged is returned when the signs could not be certain.
+func ComputeBounds(payments []Payment) (lo, hi float64, err error) {
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rate, err := Compute(sorted)
+	if err != nil {
+		return 0, 0, err
+	}
+	if math.IsNaN(rate) {
+		return rate, rate, nil
+	}
+
+	s := newSeries(sorted)
+	for width := maxError; width <= maxBoundWidth; width *= 2 {
+		loBase, hiBase := 1+rate-width/2, 1+rate+width/2
+		if loBase <= 0 {
+			break
+		}
+
+		loSign, hiSign := certainSign(s, loBase), certainSign(s, hiBase)
+		if loSign != 0 && hiSign != 0 && loSign != hiSign {
+			return rateOf(loBase, math.Inf(-1)), rateOf(hiBase, math.Inf(1)), nil
+		}
+	}
+	return 0, 0, ErrNotConverged
+}
+
+// rateOf returns the rate of base, the base less one, rounded toward dir. The
+// subtraction is exact for bases within a factor of 2 of 1.
+func rateOf(base, dir float64) float64 {
+	if base >= 0.5 && base <= 2 {
+		return base - 1
+	}
+	return math.Nextafter(base-1, dir)
+}
+
+// certainSign returns the sign of the net present value of s discounted at
+// base less one, or 0 if rounding errors make it uncertain.
+func certainSign(s series, base float64) int {
+	npv, magnitude, bound := 0.0, 0.0, 0.0
+	logBase := math.Abs(math.Log(base))
+	for i, amount := range s.amounts {
+		term := amount / math.Pow(base, s.exps[i])
+		npv += term
+		magnitude += math.Abs(term)
+
+		// Errors from the power and the division, and from the rounded
+		// exponent, magnified by its product with the log of the base.
+		bound += math.Abs(term) * epsilon * (8 + 2*s.exps[i]*logBase)
+	}
+
+	// Errors from accumulating the terms, which grow with their number.
+	n := float64(len(s.amounts))
+	bound = bound*(1+n*epsilon) + 2*n*epsilon*magnitude
+
+	switch {
+	case npv > bound:
+		return 1
+	case npv < -bound:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,33 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "testing"
+
+func TestComputeBounds(t *testing.T) {
+	for _, c := range []struct {
+		file string
+		rate float64
+	}{
+		{"single_redemption.csv", 0.1361695793742},
+		{"random.csv", 0.6924974337277},
+	} {
+		payments, err := loadPayments(c.file)
+		if err != nil {
+			t.Fatal("Error loading input:", err)
+		}
+
+		lo, hi, err := ComputeBounds(payments)
+		if err != nil {
+			t.Fatal("Error computing bounds:", err)
+		}
+		if !(lo < c.rate && c.rate < hi) {
+			t.Errorf("Expected %.13f within [%.13f, %.13f]", c.rate, lo, hi)
+		}
+		if hi-lo > 2*maxError {
+			t.Errorf("Expected bounds within %g, but were %g apart", 2*maxError, hi-lo)
+		}
+	}
+}
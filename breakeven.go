@@ -0,0 +1,31 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "time"
+
+// BreakEvenTerminalValue calculates the value that must be received on
+// terminalDate for the payments in contributions to break even.
+//
+// The value makes the undiscounted total of all payments zero. Since the net
+// present value at a rate of zero is that total, it is also the value for
+// which the internal rate of return is zero, so both definitions of break
+// even coincide. The value is negative when more has been withdrawn than
+// contributed. ErrInvalidPayments is returned when there are no
+// contributions, and ErrInvalidInterval when any is made after terminalDate.
+func BreakEvenTerminalValue(contributions []Payment, terminalDate time.Time) (float64, error) {
+	if len(contributions) == 0 {
+		return 0, ErrInvalidPayments
+	}
+
+	value := 0.0
+	for _, c := range contributions {
+		if c.Date.After(terminalDate) {
+			return 0, ErrInvalidInterval
+		}
+		value -= c.Amount
+	}
+	return value, nil
+}
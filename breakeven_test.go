@@ -0,0 +1,44 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBreakEvenTerminalValue(t *testing.T) {
+	contributions := []Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2020-06-01"), -500},
+		{parseDate("2021-01-01"), 200},
+	}
+	terminalDate := parseDate("2022-01-01")
+
+	value, err := BreakEvenTerminalValue(contributions, terminalDate)
+	if err != nil {
+		t.Fatal("Error computing break-even value:", err)
+	}
+	if value != 1300 {
+		t.Errorf("Expected %v, but was %v", 1300.0, value)
+	}
+
+	rate, err := Compute(append(append([]Payment(nil), contributions...), Payment{terminalDate, value}))
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(rate) > maxError {
+		t.Errorf("Expected %.10f, but was %.10f", 0.0, rate)
+	}
+}
+
+func TestBreakEvenTerminalValueInvalid(t *testing.T) {
+	_, err := BreakEvenTerminalValue([]Payment{
+		{parseDate("2023-01-01"), -1000},
+	}, parseDate("2022-01-01"))
+	if err != ErrInvalidInterval {
+		t.Errorf("Expected %v, but was %v", ErrInvalidInterval, err)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"sort"
+)
+
+// Classify calculates the internal rate of return of payments and the band it
+// falls into, given the thresholds between bands.
+//
+// With the thresholds sorted in increasing order, band 0 holds rates below
+// the first threshold, band i holds rates from threshold i-1, inclusive, to
+// threshold i, exclusive, and band len(bands) holds rates from the last
+// threshold. The band is -1 when the rate is NaN.
+func Classify(payments []Payment, bands []float64) (rate float64, band int, err error) {
+	rate, err = Compute(payments)
+	if err != nil {
+		return 0, 0, err
+	}
+	if math.IsNaN(rate) {
+		return rate, -1, nil
+	}
+
+	sorted := make([]float64, len(bands))
+	copy(sorted, bands)
+	sort.Float64s(sorted)
+
+	band = sort.Search(len(sorted), func(i int) bool {
+		return sorted[i] > rate
+	})
+	return rate, band, nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	bands := []float64{0.15, 0, 0.05}
+	cases := []struct {
+		amount float64
+		band   int
+	}{
+		{90, 0},
+		{101, 1},
+		{104, 1},
+		{106, 2},
+		{110, 2},
+		{120, 3},
+	}
+
+	for _, c := range cases {
+		_, band, err := Classify([]Payment{
+			{parseDate("2016-06-11"), -100},
+			{parseDate("2017-06-11"), c.amount},
+		}, bands)
+		if err != nil {
+			t.Fatal("Error classifying XIRR:", err)
+		}
+		if band != c.band {
+			t.Errorf("Expected band %d for %.0f, but was %d", c.band, c.amount, band)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"time"
+)
+
+// ComputeCommitments calculates the since-inception internal rate of return
+// of a commitment to a private equity fund, on the capital actually drawn.
+//
+// The amounts of calls, the capital drawn, and distributions, the capital
+// returned, are taken as magnitudes regardless of their signs. Calls are paid
+// and distributions received, and residualNAV, the value of the remaining
+// interest in the fund, is received on asOf. Until distributions and the
+// residual value exceed the calls, early in the life of a fund, the rate is
+// negative. ErrInvalidInterval is returned when a call or a distribution is
+// made after asOf.
+func ComputeCommitments(calls, distributions []Payment, residualNAV float64, asOf time.Time) (float64, error) {
+	payments := make([]Payment, 0, len(calls)+len(distributions)+1)
+	for _, flows := range []struct {
+		payments []Payment
+		sign     float64
+	}{{calls, -1}, {distributions, 1}} {
+		for _, p := range flows.payments {
+			if p.Date.After(asOf) {
+				return 0, ErrInvalidInterval
+			}
+			payments = append(payments, Payment{p.Date, flows.sign * math.Abs(p.Amount)})
+		}
+	}
+	return Compute(append(payments, Payment{asOf, residualNAV}))
+}
+
+// PEMetrics calculates the multiples reported for a commitment to a private
+// equity fund, along with its since-inception internal rate of return as
+// computed by ComputeCommitments.
+//
+// The paid-in capital is the total of calls. DPI is the total of
+// distributions relative to it, RVPI is residualNAV relative to it and TVPI
+// is their sum. The residual value is taken to be as of the date of the last
+// call or distribution. ErrInvalidPayments is returned when no capital was
+// paid in.
+func PEMetrics(calls, distributions []Payment, residualNAV float64) (dpi, rvpi, tvpi, irr float64, err error) {
+	if len(calls) == 0 {
+		return 0, 0, 0, 0, ErrInvalidPayments
+	}
+
+	paidIn, distributed := 0.0, 0.0
+	asOf := calls[0].Date
+	for _, c := range calls {
+		paidIn += math.Abs(c.Amount)
+		if c.Date.After(asOf) {
+			asOf = c.Date
+		}
+	}
+	for _, d := range distributions {
+		distributed += math.Abs(d.Amount)
+		if d.Date.After(asOf) {
+			asOf = d.Date
+		}
+	}
+
+	if paidIn == 0 {
+		return 0, 0, 0, 0, ErrInvalidPayments
+	}
+
+	if irr, err = ComputeCommitments(calls, distributions, residualNAV, asOf); err != nil {
+		return 0, 0, 0, 0, err
+	}
+	dpi, rvpi = distributed/paidIn, residualNAV/paidIn
+	return dpi, rvpi, dpi + rvpi, irr, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeCommitments(t *testing.T) {
+	calls := []Payment{
+		{parseDate("2015-01-01"), 300},
+		{parseDate("2016-01-01"), 300},
+		{parseDate("2017-01-01"), 250},
+		{parseDate("2018-01-01"), 150},
+	}
+	distributions := []Payment{
+		{parseDate("2019-01-01"), 100},
+		{parseDate("2020-01-01"), 250},
+		{parseDate("2021-01-01"), 400},
+		{parseDate("2022-01-01"), 500},
+	}
+	navs := map[string]float64{
+		"2016-12-31": 520,
+		"2018-12-31": 980,
+		"2022-12-31": 400,
+	}
+
+	// The rate follows a J-curve, negative while fees and early write-downs
+	// dominate and rising as distributions come in.
+	var previous float64
+	for i, asOf := range []string{"2016-12-31", "2018-12-31", "2022-12-31"} {
+		date := parseDate(asOf)
+		var drawn, returned []Payment
+		for _, c := range calls {
+			if !c.Date.After(date) {
+				drawn = append(drawn, c)
+			}
+		}
+		for _, d := range distributions {
+			if !d.Date.After(date) {
+				returned = append(returned, d)
+			}
+		}
+
+		rate, err := ComputeCommitments(drawn, returned, navs[asOf], date)
+		if err != nil {
+			t.Fatal("Error computing XIRR:", err)
+		}
+		if i == 0 && rate >= 0 {
+			t.Errorf("Expected negative rate on %s, but was %.10f", asOf, rate)
+		}
+		if i > 0 && rate <= previous {
+			t.Errorf("Expected rate above %.10f on %s, but was %.10f", previous, asOf, rate)
+		}
+		previous = rate
+	}
+	if previous <= 0 {
+		t.Errorf("Expected positive final rate, but was %.10f", previous)
+	}
+}
+
+func TestComputeCommitmentsInvalidDate(t *testing.T) {
+	_, err := ComputeCommitments([]Payment{
+		{parseDate("2015-01-01"), 300},
+	}, []Payment{
+		{parseDate("2024-01-01"), 400},
+	}, 0, parseDate("2023-01-01"))
+	if err != ErrInvalidInterval {
+		t.Errorf("Expected %v, but was %v", ErrInvalidInterval, err)
+	}
+}
+
+func TestPEMetrics(t *testing.T) {
+	// Capital called at the start of 2021 and 2022 grows at exactly 10% a
+	// year, over years of 365 days, to 1760 at the start of 2023, of which
+	// 750 is distributed and 1010 remains.
+	calls := []Payment{
+		{parseDate("2021-01-01"), -1000},
+		{parseDate("2022-01-01"), -500},
+	}
+	distributions := []Payment{
+		{parseDate("2023-01-01"), 750},
+	}
+
+	dpi, rvpi, tvpi, irr, err := PEMetrics(calls, distributions, 1010)
+	if err != nil {
+		t.Fatal("Error computing metrics:", err)
+	}
+	for _, c := range []struct {
+		name             string
+		expected, actual float64
+	}{
+		{"DPI", 0.5, dpi},
+		{"RVPI", 1010.0 / 1500, rvpi},
+		{"TVPI", 1760.0 / 1500, tvpi},
+		{"IRR", 0.1, irr},
+	} {
+		if math.Abs(c.actual-c.expected) >= maxError {
+			t.Errorf("Expected %s %.10f, but was %.10f", c.name, c.expected, c.actual)
+		}
+	}
+}
+
+func TestPEMetricsNoPaidIn(t *testing.T) {
+	_, _, _, _, err := PEMetrics([]Payment{
+		{parseDate("2021-01-01"), 0},
+	}, []Payment{
+		{parseDate("2022-01-01"), 100},
+	}, 100)
+	if err != ErrInvalidPayments {
+		t.Errorf("Expected %v, but was %v", ErrInvalidPayments, err)
+	}
+}
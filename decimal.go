@@ -0,0 +1,28 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+// ComputeDecimalRate calculates the internal rate of return like Compute and
+// returns it as a decimal rounded to scale digits after the decimal point.
+//
+// Since a decimal cannot represent NaN, ErrNotConverged is returned when the
+// computation does not converge.
+func ComputeDecimalRate(payments []Payment, scale int32) (decimal.Decimal, error) {
+	rate, err := Compute(payments)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return decimal.Zero, ErrNotConverged
+	}
+
+	return decimal.NewFromFloat(rate).Round(scale), nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestComputeDecimalRate(t *testing.T) {
+	payments, err := loadPayments("single_redemption.csv")
+	if err != nil {
+		t.Fatal("Error loading input:", err)
+	}
+
+	rate, err := Compute(payments)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+
+	for _, scale := range []int32{2, 6, 10} {
+		dec, err := ComputeDecimalRate(payments, scale)
+		if err != nil {
+			t.Fatal("Error computing decimal XIRR:", err)
+		}
+
+		expected := strconv.FormatFloat(rate, 'f', int(scale), 64)
+		if dec.StringFixed(scale) != expected {
+			t.Errorf("Expected %s at scale %d, but was %s", expected, scale, dec.StringFixed(scale))
+		}
+	}
+}
+
+func TestComputeDecimalRateNotConverged(t *testing.T) {
+	_, err := ComputeDecimalRate([]Payment{
+		{parseDate("2020-10-19"), -10000},
+		{parseDate("2020-10-19"), 1000},
+		{parseDate("2020-10-19"), 300},
+		{parseDate("2020-10-19"), 4000},
+		{parseDate("2020-10-19"), 450},
+		{parseDate("2020-10-20"), 5000},
+		{parseDate("2020-10-21"), 250},
+	}, 4)
+	if err != ErrNotConverged {
+		t.Errorf("Invalid error for non-convergence: %v", err)
+	}
+}
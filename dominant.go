@@ -0,0 +1,38 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "math"
+
+// DominantFlow finds the payment that the internal rate of return of payments
+// is most sensitive to, where accuracy of the data matters most. It returns
+// the index of the payment in payments and its sensitivity.
+//
+// The sensitivity is the change in rate per relative change in the amount of
+// the payment, the derivative of the rate with respect to the amount scaled
+// by the amount, so large payments weigh more than small ones at the same
+// date. The index is -1 and the sensitivity NaN when the rate is NaN.
+func DominantFlow(payments []Payment) (index int, sensitivity float64, err error) {
+	rate, err := Compute(payments)
+	if err != nil {
+		return 0, 0, err
+	}
+	if math.IsNaN(rate) {
+		return -1, rate, nil
+	}
+
+	sensitivities, err := rateSensitivities(payments, rate)
+	if err != nil {
+		return 0, 0, err
+	}
+	index = -1
+	for i, s := range sensitivities {
+		s *= payments[i].Amount
+		if index < 0 || math.Abs(s) > math.Abs(sensitivity) {
+			index, sensitivity = i, s
+		}
+	}
+	return index, sensitivity, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDominantFlow(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2020-01-01"), -100},
+		{parseDate("2021-01-01"), 10},
+		{parseDate("2022-01-01"), 5000},
+		{parseDate("2019-01-01"), -3000},
+	}
+
+	index, sensitivity, err := DominantFlow(payments)
+	if err != nil {
+		t.Fatal("Error finding dominant flow:", err)
+	}
+	if index != 2 {
+		t.Errorf("Expected index 2, but was %d", index)
+	}
+
+	// The sensitivity predicts the change in rate for a small relative
+	// change in the amount.
+	rate, err := Compute(payments)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	bumped := append([]Payment(nil), payments...)
+	bumped[2].Amount *= 1.0001
+	bumpedRate, err := Compute(bumped)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	expected := (bumpedRate - rate) / 0.0001
+	if math.Abs(sensitivity-expected) > 1e-3 {
+		t.Errorf("Expected %.10f, but was %.10f", expected, sensitivity)
+	}
+}
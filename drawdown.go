@@ -0,0 +1,29 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "math"
+
+// MaxDrawdown calculates the largest decline of the cumulative present value
+// of payments, discounted at rate, from its running peak.
+//
+// The cumulative present value is taken after each payment in the order of
+// dates, with the peak starting at the value after the first payment, so the
+// initial investment is not itself considered a decline.
+func MaxDrawdown(rate float64, payments []Payment) (float64, error) {
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, err
+	}
+
+	s := newSeries(sorted)
+	cumulative, peak, drawdown := 0.0, math.Inf(-1), 0.0
+	for i, amount := range s.amounts {
+		cumulative += amount / math.Pow(1.0+rate, s.exps[i])
+		peak = math.Max(peak, cumulative)
+		drawdown = math.Max(drawdown, peak-cumulative)
+	}
+	return drawdown, nil
+}
@@ -0,0 +1,28 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMaxDrawdown(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2017-06-11"), 660},
+		{parseDate("2018-06-11"), -968},
+		{parseDate("2019-06-11"), 1996.5},
+	}
+
+	// Discounted at 10%, the payments are -1000, 600, -800 and 1500.
+	drawdown, err := MaxDrawdown(0.1, payments)
+	if err != nil {
+		t.Fatal("Error computing drawdown:", err)
+	}
+	if math.Abs(drawdown-800) >= 1e-9 {
+		t.Errorf("Expected %.10f, but was %.10f", 800.0, drawdown)
+	}
+}
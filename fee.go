@@ -0,0 +1,112 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"time"
+)
+
+// A FeeKind identifies how a fee is charged.
+type FeeKind int
+
+const (
+	// ContinuousFee is charged as an annual fraction of the value of the
+	// holdings, like an expense ratio.
+	ContinuousFee FeeKind = iota
+	// ExitFee is charged as an amount on the date of the last payment.
+	ExitFee
+)
+
+// ComputeWithOneTimeFee calculates the internal rate of return of payments
+// after paying fee on feeDate, like an entry or an exit load.
+func ComputeWithOneTimeFee(payments []Payment, fee float64, feeDate time.Time) (float64, error) {
+	withFee := make([]Payment, len(payments), len(payments)+1)
+	copy(withFee, payments)
+	return Compute(append(withFee, Payment{feeDate, -fee}))
+}
+
+// ComputeWithContinuousFee calculates the internal rate of return of payments
+// after charging annualFee as a fraction of the value of the holdings every
+// year.
+//
+// The holdings are assumed to grow at the rate of return of payments before
+// the fee, so the fee reduces every year's growth factor by the same fraction
+// and the rate after it is (1 + rate) * (1 - annualFee) - 1.
+func ComputeWithContinuousFee(payments []Payment, annualFee float64) (float64, error) {
+	rate, err := Compute(payments)
+	if err != nil {
+		return 0, err
+	}
+	return drag(rate, annualFee), nil
+}
+
+// BreakevenFee calculates the fee of the given kind that reduces the internal
+// rate of return of payments to targetRate.
+//
+// A ContinuousFee is returned as an annual fraction, as accepted by
+// ComputeWithContinuousFee, while an ExitFee is returned as an amount, as
+// accepted by ComputeWithOneTimeFee on the date of the last payment. A
+// negative fee is returned when the rate is already below targetRate.
+func BreakevenFee(payments []Payment, targetRate float64, kind FeeKind) (float64, error) {
+	if kind == ContinuousFee {
+		rate, err := Compute(payments)
+		if err != nil {
+			return 0, err
+		}
+		return 1 - (1+targetRate)/(1+rate), nil
+	}
+
+	// The exit fee brings the net present value at targetRate to zero, so it
+	// is that value carried forward to the date of the last payment.
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, err
+	}
+	s := newSeries(sorted)
+	years := s.exps[len(s.exps)-1]
+	return xirr(s, targetRate, Options{}) * math.Pow(1+targetRate, years), nil
+}
+
+// ComputeWithPerfFee calculates the internal rate of return of payments after
+// a management fee of mgmtRate, charged like ComputeWithContinuousFee, and a
+// performance fee of perfRate on gains above a high-water mark.
+//
+// The gain on the date of each of valuations, whose amounts are the value of
+// the holdings before performance fees and before payments on that date, is
+// that value less the net amount invested by the payments before it. When it
+// exceeds the highest gain on earlier valuations, or zero for the first one,
+// the performance fee on the excess is paid as a payment on that date, so no
+// fee is due again until the gain makes a new high.
+func ComputeWithPerfFee(payments []Payment, valuations []Payment, mgmtRate, perfRate float64) (float64, error) {
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, err
+	}
+	charged := make([]Payment, len(sorted), len(sorted)+len(valuations))
+	copy(charged, sorted)
+
+	invested, highWaterMark, next := 0.0, 0.0, 0
+	for _, v := range normalize(valuations, Options{}) {
+		for ; next < len(sorted) && sorted[next].Date.Before(v.Date); next++ {
+			invested -= sorted[next].Amount
+		}
+		if gain := v.Amount - invested; gain > highWaterMark {
+			charged = append(charged, Payment{v.Date, -perfRate * (gain - highWaterMark)})
+			highWaterMark = gain
+		}
+	}
+
+	rate, err := Compute(charged)
+	if err != nil {
+		return 0, err
+	}
+	return drag(rate, mgmtRate), nil
+}
+
+// drag returns rate reduced by an annual cost charged as a fraction of value.
+func drag(rate, annualCost float64) float64 {
+	return (1+rate)*(1-annualCost) - 1
+}
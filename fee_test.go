@@ -0,0 +1,117 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeWithOneTimeFee(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2017-06-11"), 1100},
+	}
+
+	rate, err := ComputeWithOneTimeFee(payments, 11, parseDate("2017-06-11"))
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(rate-0.089) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", 0.089, rate)
+	}
+	if len(payments) != 2 {
+		t.Errorf("Expected payments to be unchanged, but was %v", payments)
+	}
+}
+
+func TestComputeWithContinuousFee(t *testing.T) {
+	rate, err := ComputeWithContinuousFee([]Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2017-01-05"), -500},
+		{parseDate("2018-06-11"), 1900},
+	}, 0.01)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+
+	// The fee is equivalent to scaling each payment by 0.99^t, where t is
+	// its offset in years.
+	expected, _ := Compute([]Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2017-01-05"), -500 * math.Pow(0.99, 208.0/365)},
+		{parseDate("2018-06-11"), 1900 * math.Pow(0.99, 730.0/365)},
+	})
+	if math.Abs(rate-expected) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, rate)
+	}
+}
+
+func TestBreakevenFee(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2017-06-11"), -500},
+		{parseDate("2018-06-11"), 1760},
+	}
+
+	fee, err := BreakevenFee(payments, 0.045, ContinuousFee)
+	if err != nil {
+		t.Fatal("Error computing breakeven fee:", err)
+	}
+	if math.Abs(fee-0.05) >= maxError {
+		t.Errorf("Expected continuous fee %.10f, but was %.10f", 0.05, fee)
+	}
+	rate, _ := ComputeWithContinuousFee(payments, fee)
+	if math.Abs(rate-0.045) >= maxError {
+		t.Errorf("Expected %.10f after continuous fee, but was %.10f", 0.045, rate)
+	}
+
+	fee, err = BreakevenFee(payments, 0.05, ExitFee)
+	if err != nil {
+		t.Fatal("Error computing breakeven fee:", err)
+	}
+	if math.Abs(fee-132.5) >= 1e-8 {
+		t.Errorf("Expected exit fee %.10f, but was %.10f", 132.5, fee)
+	}
+	rate, _ = ComputeWithOneTimeFee(payments, fee, parseDate("2018-06-11"))
+	if math.Abs(rate-0.05) >= maxError {
+		t.Errorf("Expected %.10f after exit fee, but was %.10f", 0.05, rate)
+	}
+}
+
+func TestComputeWithPerfFee(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2023-01-01"), 1150},
+	}
+	valuations := []Payment{
+		{parseDate("2021-01-01"), 1100},
+		{parseDate("2022-01-01"), 1050},
+		{parseDate("2023-01-01"), 1150},
+	}
+
+	// The fee is charged on the gain of 100 in 2021, not on the recovery in
+	// 2022 below that high, and on the gain of 50 above it in 2023.
+	rate, err := ComputeWithPerfFee(payments, valuations, 0, 0.2)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	expected, _ := Compute([]Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2021-01-01"), -20},
+		{parseDate("2023-01-01"), 1140},
+	})
+	if math.Abs(rate-expected) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, rate)
+	}
+
+	rate, err = ComputeWithPerfFee(payments, valuations, 0.02, 0.2)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(rate-drag(expected, 0.02)) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", drag(expected, 0.02), rate)
+	}
+}
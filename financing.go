@@ -0,0 +1,40 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "math"
+
+// ComputeWithFinancingCharge calculates the internal rate of return of
+// payments after charging financingRate on interim deficits.
+//
+// The balance is the cumulative total of the payments, in order of date,
+// made up to and including a date. While it is negative between the dates of
+// consecutive payments, the deficit is financed at financingRate compounded
+// annually, and the interest is charged as a payment on the later date. The
+// charges count toward the balance, so unpaid interest is itself financed.
+// Unlike MIRR, which finances outflows and reinvests inflows at separate
+// rates, positive balances earn nothing.
+func ComputeWithFinancingCharge(payments []Payment, financingRate float64) (float64, error) {
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, err
+	}
+
+	charged := make([]Payment, 0, 2*len(sorted))
+	balance := 0.0
+	for i, p := range sorted {
+		if i > 0 && balance < 0 {
+			years := getExp(p, sorted[i-1])
+			charge := Payment{p.Date, balance * (math.Pow(1+financingRate, years) - 1)}
+			if charge.Amount != 0 {
+				charged = append(charged, charge)
+				balance += charge.Amount
+			}
+		}
+		charged = append(charged, p)
+		balance += p.Amount
+	}
+	return Compute(charged)
+}
@@ -0,0 +1,39 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeWithFinancingCharge(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2021-01-01"), -1000},
+		{parseDate("2022-01-01"), 600},
+		{parseDate("2023-01-01"), 600},
+	}
+
+	rate, err := ComputeWithFinancingCharge(payments, 0.1)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+
+	// The deficit of 1000 in the first year is charged 100, and the deficit
+	// of 500 left in the second year is charged 50.
+	expected, _ := Compute([]Payment{
+		{parseDate("2021-01-01"), -1000},
+		{parseDate("2022-01-01"), 500},
+		{parseDate("2023-01-01"), 550},
+	})
+	if math.Abs(rate-expected) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, rate)
+	}
+
+	plain, _ := Compute(payments)
+	if plain-rate < 0.05 {
+		t.Errorf("Expected rate well below %.10f, but was %.10f", plain, rate)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "math"
+
+// EquivalentFixedRate calculates the internal rate of return of payments
+// along with the rate of a fixed deposit that would have turned the same total
+// investment into the same total value over the holding period.
+//
+// The fixed deposit is assumed to be made with the sum of all negative
+// payments on the date of the first payment and to mature with the sum of all
+// positive payments on the date of the last one. Both rates coincide for a
+// single investment redeemed once, but differ when money is invested or
+// redeemed at different times, since the fixed deposit ignores when it was.
+// fdRate is NaN when all payments are made on the same day.
+func EquivalentFixedRate(payments []Payment) (xirr float64, fdRate float64, err error) {
+	xirr, err = Compute(payments)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, 0, err
+	}
+	invested, value := 0.0, 0.0
+	for _, p := range sorted {
+		if p.Amount < 0 {
+			invested -= p.Amount
+		} else {
+			value += p.Amount
+		}
+	}
+
+	years := getExp(sorted[len(sorted)-1], sorted[0])
+	if years == 0 {
+		return xirr, math.NaN(), nil
+	}
+	return xirr, math.Pow(value/invested, 1/years) - 1, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEquivalentFixedRate(t *testing.T) {
+	xirr, fdRate, err := EquivalentFixedRate([]Payment{
+		{parseDate("2015-06-11"), -10000},
+		{parseDate("2018-06-10"), 13310},
+	})
+	if err != nil {
+		t.Fatal("Error computing rates:", err)
+	}
+	if math.Abs(xirr-0.1) >= maxError || math.Abs(fdRate-0.1) >= maxError {
+		t.Errorf("Expected both rates to be %.10f, but were %.10f and %.10f", 0.1, xirr, fdRate)
+	}
+}
+
+func TestEquivalentFixedRateLumpy(t *testing.T) {
+	// Most of the money is invested a year before redemption, so it earns
+	// much more than a deposit holding all of it for three years would.
+	xirr, fdRate, err := EquivalentFixedRate([]Payment{
+		{parseDate("2015-06-11"), -1000},
+		{parseDate("2017-06-10"), -9000},
+		{parseDate("2018-06-10"), 11000},
+	})
+	if err != nil {
+		t.Fatal("Error computing rates:", err)
+	}
+
+	expected := math.Pow(1.1, 1.0/3) - 1
+	if math.Abs(fdRate-expected) >= maxError {
+		t.Errorf("Expected fixed deposit rate %.10f, but was %.10f", expected, fdRate)
+	}
+	if xirr <= 2*fdRate {
+		t.Errorf("Expected XIRR %.10f to be well above fixed deposit rate %.10f", xirr, fdRate)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "time"
+
+// A FlowType classifies a payment for attributing the rate of return.
+type FlowType int
+
+const (
+	// Capital payments are investments, redemptions, returns of capital and
+	// terminal values.
+	Capital FlowType = iota
+	// Income payments are dividends, interest and other distributions.
+	Income
+)
+
+// A TypedPayment represents a payment along with its type.
+type TypedPayment struct {
+	Payment
+	Type FlowType
+}
+
+// A Breakdown represents the internal rate of return of a series of payments
+// attributed to their types.
+type Breakdown struct {
+	Rate    float64
+	Capital float64
+	Income  float64
+}
+
+// ComputeByFlowType calculates the internal rate of return of payments and
+// attributes it to capital and income.
+//
+// The capital component is the internal rate of return of the capital
+// payments alone, which is the return had no income been received. The income
+// component is the difference between the overall rate and the capital
+// component, so that the two always add up to the overall rate.
+// ErrInvalidPayments is returned if the capital payments alone do not include
+// both positive and negative payments.
+func ComputeByFlowType(payments []TypedPayment) (Breakdown, error) {
+	all := make([]Payment, len(payments))
+	var capital []Payment
+	for i, p := range payments {
+		all[i] = p.Payment
+		if p.Type == Capital {
+			capital = append(capital, p.Payment)
+		}
+	}
+
+	rate, err := Compute(all)
+	if err != nil {
+		return Breakdown{}, err
+	}
+	capitalRate, err := Compute(capital)
+	if err != nil {
+		return Breakdown{}, err
+	}
+
+	return Breakdown{rate, capitalRate, rate - capitalRate}, nil
+}
+
+// DecomposeTotalReturn calculates the internal rate of return of an
+// investment including both price and income payments, and decomposes it
+// into price and income returns.
+//
+// Both legs share the terminal value, received on terminalDate, which is
+// treated as a price payment. As in ComputeByFlowType, the price return is
+// the internal rate of return of priceFlows and the terminal value alone,
+// while the income return is the remainder of the total return.
+func DecomposeTotalReturn(priceFlows, incomeFlows []Payment, terminalValue float64, terminalDate time.Time) (total, price, income float64, err error) {
+	payments := make([]TypedPayment, 0, len(priceFlows)+len(incomeFlows)+1)
+	for _, p := range priceFlows {
+		payments = append(payments, TypedPayment{p, Capital})
+	}
+	for _, p := range incomeFlows {
+		payments = append(payments, TypedPayment{p, Income})
+	}
+	payments = append(payments, TypedPayment{Payment{terminalDate, terminalValue}, Capital})
+
+	b, err := ComputeByFlowType(payments)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return b.Rate, b.Capital, b.Income, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeByFlowType(t *testing.T) {
+	b, err := ComputeByFlowType([]TypedPayment{
+		{Payment{parseDate("2016-06-11"), -1000}, Capital},
+		{Payment{parseDate("2016-12-10"), 30}, Income},
+		{Payment{parseDate("2017-06-11"), 30}, Income},
+		{Payment{parseDate("2017-06-11"), 1050}, Capital},
+	})
+	if err != nil {
+		t.Fatal("Error computing breakdown:", err)
+	}
+
+	if math.Abs(b.Capital-0.05) >= maxError {
+		t.Errorf("Expected capital rate %.10f, but was %.10f", 0.05, b.Capital)
+	}
+	if b.Income <= 0.05 || b.Income >= 0.07 {
+		t.Errorf("Expected income rate around %.10f, but was %.10f", 0.06, b.Income)
+	}
+	if math.Abs(b.Capital+b.Income-b.Rate) >= maxError {
+		t.Errorf("Expected components to add up to %.10f, but was %.10f", b.Rate, b.Capital+b.Income)
+	}
+}
+
+func TestComputeByFlowTypeIncomeOnly(t *testing.T) {
+	_, err := ComputeByFlowType([]TypedPayment{
+		{Payment{parseDate("2016-06-11"), -1000}, Capital},
+		{Payment{parseDate("2017-06-11"), 1100}, Income},
+	})
+	if err != ErrInvalidPayments {
+		t.Errorf("Invalid error without capital returned: %v", err)
+	}
+}
+
+func TestDecomposeTotalReturn(t *testing.T) {
+	total, price, income, err := DecomposeTotalReturn(
+		[]Payment{{parseDate("2016-06-11"), -1000}},
+		[]Payment{{parseDate("2016-12-10"), 20}, {parseDate("2017-06-11"), 30}},
+		1050, parseDate("2017-06-11"),
+	)
+	if err != nil {
+		t.Fatal("Error decomposing return:", err)
+	}
+
+	expected, _ := Compute([]Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2016-12-10"), 20},
+		{parseDate("2017-06-11"), 1080},
+	})
+	if math.Abs(total-expected) >= maxError {
+		t.Errorf("Expected total %.10f, but was %.10f", expected, total)
+	}
+	if math.Abs(price-0.05) >= maxError {
+		t.Errorf("Expected price return %.10f, but was %.10f", 0.05, price)
+	}
+	if math.Abs(income-(expected-0.05)) >= maxError {
+		t.Errorf("Expected income return %.10f, but was %.10f", expected-0.05, income)
+	}
+}
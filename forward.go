@@ -0,0 +1,67 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ForwardRate calculates the internal rate of return of payments over the
+// full period they span and over the front period up to t1, and derives the
+// implied forward rate over the back period from t1.
+//
+// The front period consists of the payments up to and including t1 followed
+// by a redemption of interimValue, the value of the holdings at t1. The
+// forward rate satisfies (1+full)^T = (1+front)^t * (1+forward)^(T-t), where
+// T and t are the number of years from the first payment to the last one and
+// to t1 respectively. ErrInvalidInterval is returned unless t1 falls strictly
+// between the first and the last payments.
+func ForwardRate(payments []Payment, t1 time.Time, interimValue float64) (full, front, forward float64, err error) {
+	full, err = Compute(payments)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	first, last := sorted[0], sorted[len(sorted)-1]
+	if !t1.After(first.Date) || !t1.Before(last.Date) {
+		return 0, 0, 0, ErrInvalidInterval
+	}
+
+	frontPayments, _ := splitPayments(sorted, t1, interimValue)
+	front, err = Compute(frontPayments)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	years := getExp(last, first)
+	frontYears := getExp(Payment{Date: t1}, first)
+	growth := math.Pow(1+full, years) / math.Pow(1+front, frontYears)
+	forward = math.Pow(growth, 1/(years-frontYears)) - 1
+	return full, front, forward, nil
+}
+
+// splitPayments splits payments sorted by date into those up to and including
+// date followed by a redemption of value, and an investment of value followed
+// by those after date.
+func splitPayments(sorted []Payment, date time.Time, value float64) (front, back []Payment) {
+	i := sort.Search(len(sorted), func(i int) bool {
+		return sorted[i].Date.After(date)
+	})
+
+	front = make([]Payment, i, i+1)
+	copy(front, sorted[:i])
+	front = append(front, Payment{date, value})
+
+	back = make([]Payment, 1, len(sorted)-i+1)
+	back[0] = Payment{date, -value}
+	back = append(back, sorted[i:]...)
+	return front, back
+}
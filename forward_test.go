@@ -0,0 +1,38 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestForwardRate(t *testing.T) {
+	// 10% a year for two years followed by 20% in the third.
+	full, front, forward, err := ForwardRate([]Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2019-06-11"), 1452},
+	}, parseDate("2018-06-11"), 1210)
+	if err != nil {
+		t.Fatal("Error computing forward rate:", err)
+	}
+
+	expected := []float64{math.Pow(1.452, 1.0/3) - 1, 0.1, 0.2}
+	for i, rate := range []float64{full, front, forward} {
+		if math.Abs(rate-expected[i]) >= maxError {
+			t.Errorf("Expected rate %d to be %.10f, but was %.10f", i, expected[i], rate)
+		}
+	}
+}
+
+func TestForwardRateOutside(t *testing.T) {
+	_, _, _, err := ForwardRate([]Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2019-06-11"), 1452},
+	}, parseDate("2019-06-11"), 1452)
+	if err != ErrInvalidInterval {
+		t.Errorf("Invalid error for date outside the series: %v", err)
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/cskr/go-xirr
 
 go 1.14
+
+require github.com/shopspring/decimal v1.3.1
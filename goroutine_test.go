@@ -0,0 +1,49 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+//go:build go1.16
+// +build go1.16
+
+package xirr
+
+import (
+	"runtime"
+	"runtime/metrics"
+	"testing"
+)
+
+func TestNoGoroutines(t *testing.T) {
+	sample := []metrics.Sample{{Name: "/sched/goroutines-created:goroutines"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() == metrics.KindBad {
+		t.Skip("Goroutine creation is not counted by this runtime")
+	}
+
+	var series [][]Payment
+	for _, file := range []string{"single_redemption.csv", "random.csv"} {
+		payments, err := loadPayments(file)
+		if err != nil {
+			t.Fatal("Error loading input:", err)
+		}
+		series = append(series, payments)
+	}
+
+	// Start the background workers of the garbage collector beforehand, so
+	// they are not counted.
+	runtime.GC()
+	metrics.Read(sample)
+	before := sample[0].Value.Uint64()
+
+	for _, payments := range series {
+		Compute(payments)
+		ComputeWithOptions(payments, Options{CompensatedSum: true, CoarseTolerance: 1e-4})
+		ComputeVerbose(payments, Options{RecordHistory: true})
+		NewSolver(Options{}).Solve(payments)
+	}
+
+	metrics.Read(sample)
+	if created := sample[0].Value.Uint64() - before; created != 0 {
+		t.Errorf("Expected no goroutines to be created, but %d were", created)
+	}
+}
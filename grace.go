@@ -0,0 +1,33 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "time"
+
+// ComputeWithGracePeriod calculates the base internal rate of return of
+// payments when those made during a grace period, up to and including
+// graceEnd, are discounted at a different rate.
+//
+// Payments in the grace period are discounted from the first payment at the
+// base rate plus graceRateAdjustment, while the remaining ones are discounted
+// at the base rate. The returned base rate is the one at which the net
+// present value of the payments is zero. With a zero adjustment, it is the
+// same as the rate returned by Compute.
+func ComputeWithGracePeriod(payments []Payment, graceEnd time.Time, graceRateAdjustment float64) (float64, error) {
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, err
+	}
+
+	s := newSeries(sorted)
+	s.shifts = make([]float64, len(sorted))
+	for i, p := range sorted {
+		if !p.Date.After(graceEnd) {
+			s.shifts[i] = graceRateAdjustment
+		}
+	}
+
+	return solve(s, Options{}).Rate, nil
+}
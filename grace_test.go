@@ -0,0 +1,46 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeWithGracePeriod(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2017-06-11"), -500},
+		{parseDate("2018-06-11"), 200},
+		{parseDate("2019-06-11"), 1600},
+	}
+	graceEnd := parseDate("2017-06-11")
+
+	plain, err := Compute(payments)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	rate, err := ComputeWithGracePeriod(payments, graceEnd, 0)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(rate-plain) >= maxError {
+		t.Errorf("Expected %.10f without adjustment, but was %.10f", plain, rate)
+	}
+
+	// The investment a year in is discounted at 2% less than the rest, which
+	// increases its weight, and so lowers the base rate.
+	rate, err = ComputeWithGracePeriod(payments, graceEnd, -0.02)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	npv := -1000 - 500/(1+rate-0.02) + 200/math.Pow(1+rate, 2) + 1600/math.Pow(1+rate, 3)
+	if math.Abs(npv) >= 1e-9 {
+		t.Errorf("Expected zero net present value at %.10f, but was %.10f", rate, npv)
+	}
+	if rate >= plain {
+		t.Errorf("Expected base rate below %.10f, but was %.10f", plain, rate)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// HarvestedRate calculates the after-tax internal rate of return of payments
+// when losses are harvested on harvestDates, given the market value of the
+// holdings on each of them.
+//
+// The cost basis grows with every negative payment. Positive payments before
+// the last are treated as returns of capital that reduce the basis, with any
+// amount beyond it taxed as gain, and the last payment as the liquidation of
+// the holdings, whose gain over the basis is taxed. A loss on liquidation
+// reduces the tax due on other income by taxRate times the loss, which is
+// counted as an inflow.
+//
+// On a harvest date whose market value is below the basis, the holdings are
+// sold and immediately bought back, realizing the loss. The sale and the
+// purchase cancel each other out, so only the resulting tax benefit is added
+// as a payment, while the basis drops to the market value. This defers tax
+// to the liquidation instead of avoiding it. Harvest dates on the date of a
+// payment are applied after it, and ErrInvalidInterval is returned unless
+// they fall strictly between the first and the last payments.
+func HarvestedRate(payments []Payment, harvestDates []time.Time, taxRate float64, marketValue func(time.Time) float64) (float64, error) {
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, err
+	}
+
+	dates := make([]time.Time, len(harvestDates))
+	copy(dates, harvestDates)
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	first, last := sorted[0].Date, sorted[len(sorted)-1].Date
+	for _, date := range dates {
+		if !date.After(first) || !date.Before(last) {
+			return 0, ErrInvalidInterval
+		}
+	}
+
+	taxed := make([]Payment, 0, len(sorted)+len(dates))
+	basis := 0.0
+	for i, p := range sorted {
+		for len(dates) > 0 && dates[0].Before(p.Date) {
+			if value := marketValue(dates[0]); value < basis {
+				taxed = append(taxed, Payment{dates[0], taxRate * (basis - value)})
+				basis = value
+			}
+			dates = dates[1:]
+		}
+
+		switch {
+		case p.Amount < 0:
+			basis -= p.Amount
+		case i == len(sorted)-1:
+			p.Amount -= taxRate * (p.Amount - basis)
+		default:
+			gain := math.Max(p.Amount-basis, 0)
+			basis -= p.Amount - gain
+			p.Amount -= taxRate * gain
+		}
+		taxed = append(taxed, p)
+	}
+	return Compute(taxed)
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHarvestedRate(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2023-01-01"), 1500},
+	}
+	marketValue := func(time.Time) float64 { return 700 }
+
+	unharvested, err := HarvestedRate(payments, nil, 0.3, marketValue)
+	if err != nil {
+		t.Fatal("Error computing harvested rate:", err)
+	}
+	taxed, err := Compute([]Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2023-01-01"), 1350},
+	})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(unharvested-taxed) > maxError {
+		t.Errorf("Expected %.10f, but was %.10f", taxed, unharvested)
+	}
+
+	harvested, err := HarvestedRate(payments, []time.Time{parseDate("2020-07-01")}, 0.3, marketValue)
+	if err != nil {
+		t.Fatal("Error computing harvested rate:", err)
+	}
+	expected, err := Compute([]Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2020-07-01"), 90},
+		{parseDate("2023-01-01"), 1260},
+	})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(harvested-expected) > maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, harvested)
+	}
+	if harvested <= unharvested {
+		t.Errorf("Expected harvested rate above %.10f, but was %.10f", unharvested, harvested)
+	}
+}
+
+func TestHarvestedRateInvalidDate(t *testing.T) {
+	_, err := HarvestedRate([]Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2023-01-01"), 1500},
+	}, []time.Time{parseDate("2023-01-01")}, 0.3, func(time.Time) float64 { return 0 })
+	if err != ErrInvalidInterval {
+		t.Errorf("Expected %v, but was %v", ErrInvalidInterval, err)
+	}
+}
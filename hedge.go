@@ -0,0 +1,23 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+// ComputeHedged calculates the internal rate of return of payments for
+// holdings whose currency exposure is hedged at annualHedgeCost, a fraction
+// of the value hedged every year.
+//
+// The cost accrues for as long as money stays invested, in proportion to the
+// value hedged, so it is modelled like a continuous fee: the holdings are
+// assumed to grow at the unhedged rate of payments, and the hedged rate is
+// (1 + rate) * (1 - annualHedgeCost) - 1. Unlike a fee, the cost depends on
+// the interest rate differential between the currencies, which may be
+// negative and so add to the return.
+func ComputeHedged(payments []Payment, annualHedgeCost float64) (float64, error) {
+	rate, err := Compute(payments)
+	if err != nil {
+		return 0, err
+	}
+	return drag(rate, annualHedgeCost), nil
+}
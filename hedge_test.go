@@ -0,0 +1,30 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeHedged(t *testing.T) {
+	payments, err := loadPayments("single_redemption.csv")
+	if err != nil {
+		t.Fatal("Error loading input:", err)
+	}
+
+	unhedged, err := Compute(payments)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	hedged, err := ComputeHedged(payments, 0.02)
+	if err != nil {
+		t.Fatal("Error computing hedged XIRR:", err)
+	}
+
+	if hedged >= unhedged || math.Abs(unhedged-hedged-0.02) >= 0.005 {
+		t.Errorf("Expected about %.10f, but was %.10f", unhedged-0.02, hedged)
+	}
+}
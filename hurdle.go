@@ -0,0 +1,48 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrAmbiguousHurdle is returned by MeetsHurdle when the rate and the net
+// present value at the hurdle disagree, as happens with series that have
+// several internal rates of return.
+var ErrAmbiguousHurdle = errors.New("rate and net present value disagree on hurdle")
+
+// MeetsHurdle calculates the internal rate of return of payments and whether
+// it meets or exceeds hurdle.
+//
+// The decision is cross-checked with the net present value of payments
+// discounted at hurdle, which is not negative for investments that meet it.
+// For series that start with an inflow, like loans, the sign is reversed.
+// When the rate is NaN, the decision is made from the net present value
+// alone.
+func MeetsHurdle(payments []Payment, hurdle float64) (rate float64, meets bool, err error) {
+	rate, err = Compute(payments)
+	if err != nil {
+		return 0, false, err
+	}
+
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, false, err
+	}
+	npv := xirr(newSeries(sorted), hurdle, Options{})
+	if sorted[0].Amount > 0 {
+		npv = -npv
+	}
+	if math.IsNaN(rate) {
+		return rate, npv >= 0, nil
+	}
+
+	meets = rate >= hurdle
+	if meets != (npv >= 0) && math.Abs(rate-hurdle) > maxError {
+		return rate, meets, ErrAmbiguousHurdle
+	}
+	return rate, meets, nil
+}
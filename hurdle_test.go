@@ -0,0 +1,50 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMeetsHurdle(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2021-01-01"), 100},
+		{parseDate("2022-01-01"), 1100},
+	}
+
+	rate, meets, err := MeetsHurdle(payments, 0.08)
+	if err != nil {
+		t.Fatal("Error checking hurdle:", err)
+	}
+	if math.Abs(rate-0.1) > 1e-3 {
+		t.Errorf("Expected rate near 0.1, but was %.10f", rate)
+	}
+	if !meets {
+		t.Errorf("Expected rate %.10f to meet hurdle 0.08", rate)
+	}
+
+	_, meets, err = MeetsHurdle(payments, 0.12)
+	if err != nil {
+		t.Fatal("Error checking hurdle:", err)
+	}
+	if meets {
+		t.Errorf("Expected rate %.10f to fail hurdle 0.12", rate)
+	}
+}
+
+func TestMeetsHurdleLoan(t *testing.T) {
+	_, meets, err := MeetsHurdle([]Payment{
+		{parseDate("2020-01-01"), 1000},
+		{parseDate("2022-01-01"), -1200},
+	}, 0.05)
+	if err != nil {
+		t.Fatal("Error checking hurdle:", err)
+	}
+	if !meets {
+		t.Error("Expected loan cost to meet hurdle 0.05")
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "time"
+
+// ComputeIndexed calculates the nominal internal rate of return of payments
+// indexed to inflation, whose amounts in realFlows are in terms of money on
+// baseDate.
+//
+// Each real amount is scaled to a nominal one by the ratio of
+// inflationIndex, which must be positive, on its date to that on baseDate.
+// The real rate is the rate of realFlows, as computed by Compute.
+func ComputeIndexed(realFlows []Payment, inflationIndex func(time.Time) float64, baseDate time.Time) (float64, error) {
+	base := inflationIndex(baseDate)
+	nominal := make([]Payment, len(realFlows))
+	for i, p := range realFlows {
+		nominal[i] = Payment{p.Date, p.Amount * inflationIndex(p.Date) / base}
+	}
+	return Compute(nominal)
+}
@@ -0,0 +1,38 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestComputeIndexed(t *testing.T) {
+	baseDate := parseDate("2020-01-01")
+
+	// The index rises by 3 points a year from 100 on baseDate.
+	index := func(date time.Time) float64 {
+		return 100 + 3*date.Sub(baseDate).Hours()/24/365
+	}
+
+	rate, err := ComputeIndexed([]Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2020-12-31"), 50},
+		{parseDate("2022-12-31"), 1050},
+	}, index, baseDate)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+
+	expected, _ := Compute([]Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2020-12-31"), 51.5},
+		{parseDate("2022-12-31"), 1144.5},
+	})
+	if math.Abs(rate-expected) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, rate)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+)
+
+// knownErrors are the errors restored as themselves when a Result is decoded
+// from JSON, so they can still be compared with the error variables.
+var knownErrors = []error{
+	ErrInvalidPayments,
+	ErrNotConverged,
+	ErrHoldingTooShort,
+	ErrTooManyPayments,
+	ErrMismatchedLengths,
+	ErrInvalidDaysPerYear,
+	ErrTargetNotReached,
+	ErrInvalidInterval,
+	ErrInvalidPeriods,
+	ErrInvalidSerialDate,
+	ErrAmbiguousHurdle,
+	ErrNegativeTrim,
+	ErrInvalidStdDev,
+}
+
+type resultJSON struct {
+	Index       int         `json:"index"`
+	Rate        jsonFloat   `json:"rate"`
+	Iterations  int         `json:"iterations"`
+	Diagnostics Diagnostics `json:"diagnostics"`
+	Err         string      `json:"err,omitempty"`
+}
+
+type diagnosticsJSON struct {
+	Guess            jsonFloat   `json:"guess"`
+	IterationHistory []jsonFloat `json:"iterationHistory,omitempty"`
+	Warnings         []string    `json:"warnings,omitempty"`
+}
+
+// MarshalJSON encodes r as a JSON object, for caching it outside the process.
+// Rates that are NaN or infinite are encoded as null, and Err as its message.
+func (r Result) MarshalJSON() ([]byte, error) {
+	v := resultJSON{
+		Index:       r.Index,
+		Rate:        jsonFloat(r.Rate),
+		Iterations:  r.Iterations,
+		Diagnostics: r.Diagnostics,
+	}
+	if r.Err != nil {
+		v.Err = r.Err.Error()
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes r from JSON produced by MarshalJSON. Rates encoded as
+// null are decoded as NaN, and errors defined by this package as themselves.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var v resultJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*r = Result{
+		Index:       v.Index,
+		Rate:        float64(v.Rate),
+		Iterations:  v.Iterations,
+		Diagnostics: v.Diagnostics,
+	}
+	if v.Err != "" {
+		r.Err = errors.New(v.Err)
+		for _, err := range knownErrors {
+			if err.Error() == v.Err {
+				r.Err = err
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalJSON encodes d as a JSON object, with rates that are NaN or infinite
+// encoded as null.
+func (d Diagnostics) MarshalJSON() ([]byte, error) {
+	v := diagnosticsJSON{Guess: jsonFloat(d.Guess), Warnings: d.Warnings}
+	for _, rate := range d.IterationHistory {
+		v.IterationHistory = append(v.IterationHistory, jsonFloat(rate))
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes d from JSON produced by MarshalJSON, with rates
+// encoded as null decoded as NaN.
+func (d *Diagnostics) UnmarshalJSON(data []byte) error {
+	var v diagnosticsJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*d = Diagnostics{Guess: float64(v.Guess), Warnings: v.Warnings}
+	for _, rate := range v.IterationHistory {
+		d.IterationHistory = append(d.IterationHistory, float64(rate))
+	}
+	return nil
+}
+
+// jsonFloat is a float64 that is encoded as null in JSON when it is not
+// finite.
+type jsonFloat float64
+
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+		return []byte("null"), nil
+	}
+	return strconv.AppendFloat(nil, float64(f), 'g', -1, 64), nil
+}
+
+func (f *jsonFloat) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*f = jsonFloat(math.NaN())
+		return nil
+	}
+	v, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+	*f = jsonFloat(v)
+	return nil
+}
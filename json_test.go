@@ -0,0 +1,72 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestResultJSON(t *testing.T) {
+	payments, err := loadPayments("random.csv")
+	if err != nil {
+		t.Fatal("Error loading payments:", err)
+	}
+
+	res, err := ComputeVerbose(payments, Options{RecordHistory: true})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	res.Diagnostics.Warnings = append(res.Diagnostics.Warnings, "test warning")
+	decoded := roundTrip(t, res)
+	if !reflect.DeepEqual(decoded, res) {
+		t.Errorf("Expected %+v, but was %+v", res, decoded)
+	}
+}
+
+func TestResultJSONNaN(t *testing.T) {
+	res, _ := ComputeVerbose([]Payment{
+		{parseDate("2020-10-19"), -10000},
+		{parseDate("2020-10-19"), 1000},
+		{parseDate("2020-10-19"), 300},
+		{parseDate("2020-10-19"), 4000},
+		{parseDate("2020-10-19"), 450},
+		{parseDate("2020-10-20"), 5000},
+		{parseDate("2020-10-21"), 250},
+	}, Options{})
+	if !math.IsNaN(res.Rate) {
+		t.Fatalf("Expected %.10f, but was %.10f", math.NaN(), res.Rate)
+	}
+
+	decoded := roundTrip(t, res)
+	if !math.IsNaN(decoded.Rate) || !math.IsNaN(decoded.Diagnostics.Guess) {
+		t.Errorf("Expected NaN rate and guess, but was %+v", decoded)
+	}
+	if decoded.Iterations != res.Iterations {
+		t.Errorf("Expected %d iterations, but was %d", res.Iterations, decoded.Iterations)
+	}
+}
+
+func TestResultJSONErr(t *testing.T) {
+	res, _ := ComputeVerbose([]Payment{{parseDate("2020-01-01"), -100}}, Options{})
+	decoded := roundTrip(t, res)
+	if decoded.Err != ErrInvalidPayments {
+		t.Errorf("Expected %v, but was %v", ErrInvalidPayments, decoded.Err)
+	}
+}
+
+func roundTrip(t *testing.T, res Result) Result {
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal("Error encoding result:", err)
+	}
+	var decoded Result
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal("Error decoding result:", err)
+	}
+	return decoded
+}
@@ -0,0 +1,32 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"time"
+)
+
+// ComputeLeveraged calculates the internal rate of return of payments for a
+// position partly financed by borrowing, net of the cost of borrowing.
+//
+// The payments are those of the whole position. The amount borrowed is
+// received on borrowDate and repaid on repayDate along with interest at
+// borrowRate, compounded annually, so the investor's own payments are
+// payments less the loan. ErrInvalidInterval is returned unless repayDate is
+// after borrowDate.
+func ComputeLeveraged(payments []Payment, borrowed float64, borrowRate float64, borrowDate, repayDate time.Time) (float64, error) {
+	if !repayDate.After(borrowDate) {
+		return 0, ErrInvalidInterval
+	}
+
+	loan := Payment{borrowDate, borrowed}
+	repayment := Payment{repayDate, 0}
+	repayment.Amount = -borrowed * math.Pow(1+borrowRate, getExp(repayment, loan))
+
+	levered := make([]Payment, len(payments), len(payments)+2)
+	copy(levered, payments)
+	return Compute(append(levered, loan, repayment))
+}
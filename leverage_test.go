@@ -0,0 +1,51 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeLeveraged(t *testing.T) {
+	start, end := parseDate("2021-01-01"), parseDate("2022-01-01")
+
+	for _, c := range []struct {
+		value     float64
+		unlevered float64
+		levered   float64
+	}{
+		{1200, 0.2, 0.35},
+		{900, -0.1, -0.25},
+	} {
+		payments := []Payment{{start, -1000}, {end, c.value}}
+		unlevered, err := Compute(payments)
+		if err != nil {
+			t.Fatal("Error computing XIRR:", err)
+		}
+		levered, err := ComputeLeveraged(payments, 500, 0.05, start, end)
+		if err != nil {
+			t.Fatal("Error computing leveraged XIRR:", err)
+		}
+
+		if math.Abs(unlevered-c.unlevered) > maxError {
+			t.Errorf("Expected %.10f, but was %.10f", c.unlevered, unlevered)
+		}
+		if math.Abs(levered-c.levered) > maxError {
+			t.Errorf("Expected %.10f, but was %.10f", c.levered, levered)
+		}
+	}
+}
+
+func TestComputeLeveragedInvalidDates(t *testing.T) {
+	date := parseDate("2021-01-01")
+	_, err := ComputeLeveraged([]Payment{
+		{date, -1000},
+		{parseDate("2022-01-01"), 1200},
+	}, 500, 0.05, date, date)
+	if err != ErrInvalidInterval {
+		t.Errorf("Expected %v, but was %v", ErrInvalidInterval, err)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidPeriods is returned by LinkPeriods when no periods are provided or
+// when the length of a period is not positive.
+var ErrInvalidPeriods = errors.New("periods must have positive lengths")
+
+// LinkPeriods geometrically links the annualized rates of consecutive periods,
+// lasting the corresponding number of years in periodYears. It returns the
+// cumulative return over all the periods and its annualized rate.
+//
+// The cumulative return is the product of (1 + rate) ^ years over the periods,
+// less one, and the annualized rate is the rate that compounds to it over the
+// total number of years.
+func LinkPeriods(periodRates []float64, periodYears []float64) (cumulative float64, annualized float64, err error) {
+	if len(periodRates) != len(periodYears) {
+		return 0, 0, ErrMismatchedLengths
+	}
+	if len(periodYears) == 0 {
+		return 0, 0, ErrInvalidPeriods
+	}
+
+	growth, years := 1.0, 0.0
+	for i, r := range periodRates {
+		if periodYears[i] <= 0 {
+			return 0, 0, ErrInvalidPeriods
+		}
+		growth *= math.Pow(1.0+r, periodYears[i])
+		years += periodYears[i]
+	}
+
+	return growth - 1.0, math.Pow(growth, 1.0/years) - 1.0, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLinkPeriods(t *testing.T) {
+	dates := []string{"2016-06-11", "2017-06-11", "2018-06-11", "2019-06-11"}
+	values := []float64{1000, 1100, 1045, 1254}
+
+	var rates, years []float64
+	for i := 1; i < len(dates); i++ {
+		rate, err := Compute([]Payment{
+			{parseDate(dates[i-1]), -values[i-1]},
+			{parseDate(dates[i]), values[i]},
+		})
+		if err != nil {
+			t.Fatal("Error computing XIRR:", err)
+		}
+		rates = append(rates, rate)
+		years = append(years, 1)
+	}
+
+	cumulative, annualized, err := LinkPeriods(rates, years)
+	if err != nil {
+		t.Fatal("Error linking periods:", err)
+	}
+	if math.Abs(cumulative-0.254) >= maxError {
+		t.Errorf("Expected cumulative return %.10f, but was %.10f", 0.254, cumulative)
+	}
+
+	direct, err := Compute([]Payment{
+		{parseDate(dates[0]), -values[0]},
+		{parseDate(dates[3]), values[3]},
+	})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(annualized-direct) >= 1e-3 {
+		t.Errorf("Expected annualized rate close to %.10f, but was %.10f", direct, annualized)
+	}
+}
+
+func TestLinkPeriodsInvalid(t *testing.T) {
+	if _, _, err := LinkPeriods([]float64{0.1, 0.2}, []float64{1}); err != ErrMismatchedLengths {
+		t.Errorf("Invalid error for mismatched lengths: %v", err)
+	}
+	if _, _, err := LinkPeriods([]float64{0.1}, []float64{0}); err != ErrInvalidPeriods {
+		t.Errorf("Invalid error for empty period: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "math"
+
+const maxRemovalSets = 1000
+
+// OutlierDiagnosis searches for the smallest set of payments whose removal
+// makes the XIRR computation converge. It returns the indices of the removed
+// payments, in increasing order, along with the resulting rate.
+//
+// Removal sets of up to maxRemovals payments are tried in order of size, and
+// lexicographically within a size, with no payments removed tried first.
+// Sets that leave payments without both signs are skipped. To bound the
+// search, at most 1000 removal sets are tried before ErrNotConverged is
+// returned.
+func OutlierDiagnosis(payments []Payment, maxRemovals int) (removed []int, rate float64, err error) {
+	if err := validatePayments(payments); err != nil {
+		return nil, 0, err
+	}
+
+	tried := 0
+	for size := 0; size <= maxRemovals && size <= len(payments); size++ {
+		set := make([]int, size)
+		for i := range set {
+			set[i] = i
+		}
+
+		for {
+			if tried == maxRemovalSets {
+				return nil, math.NaN(), ErrNotConverged
+			}
+			tried++
+
+			remaining := withoutIndices(payments, set)
+			if validatePayments(remaining) == nil {
+				rate, _ := Compute(remaining)
+				if !math.IsNaN(rate) && !math.IsInf(rate, 0) {
+					return set, rate, nil
+				}
+			}
+
+			if !nextCombination(set, len(payments)) {
+				break
+			}
+		}
+	}
+
+	return nil, math.NaN(), ErrNotConverged
+}
+
+func withoutIndices(payments []Payment, indices []int) []Payment {
+	result := make([]Payment, 0, len(payments)-len(indices))
+	j := 0
+	for i, p := range payments {
+		if j < len(indices) && indices[j] == i {
+			j++
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// nextCombination advances set, a strictly increasing sequence of indices
+// below n, to the next one in lexicographic order. It returns false when set
+// is already the last one.
+func nextCombination(set []int, n int) bool {
+	k := len(set)
+	i := k - 1
+	for i >= 0 && set[i] == n-k+i {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+
+	set[i]++
+	for j := i + 1; j < k; j++ {
+		set[j] = set[j-1] + 1
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOutlierDiagnosis(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2020-10-19"), -10000},
+		{parseDate("2020-10-19"), 1000},
+		{parseDate("2020-10-19"), 300},
+		{parseDate("2020-10-19"), 4000},
+		{parseDate("2020-10-19"), 450},
+		{parseDate("2020-10-20"), 5000},
+		{parseDate("2020-10-21"), 250},
+	}
+
+	removed, rate, err := OutlierDiagnosis(payments, 2)
+	if err != nil {
+		t.Fatal("Error diagnosing outliers:", err)
+	}
+	if len(removed) != 1 || removed[0] != 1 {
+		t.Errorf("Expected [1] to be removed, but was %v", removed)
+	}
+	if math.Abs(rate) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", 0.0, rate)
+	}
+
+	_, _, err = OutlierDiagnosis(payments, 0)
+	if err != ErrNotConverged {
+		t.Errorf("Invalid error without removals: %v", err)
+	}
+}
+
+func TestOutlierDiagnosisConverged(t *testing.T) {
+	payments, err := loadPayments("single_redemption.csv")
+	if err != nil {
+		t.Fatal("Error loading input:", err)
+	}
+
+	removed, rate, err := OutlierDiagnosis(payments, 1)
+	if err != nil {
+		t.Fatal("Error diagnosing outliers:", err)
+	}
+	if len(removed) != 0 {
+		t.Errorf("Expected no removals, but was %v", removed)
+	}
+	if math.Abs(rate-0.1361695793742) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", 0.1361695793742, rate)
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "time"
+
+// ComputeFromPercents calculates the internal rate of return of contributions
+// expressed as fractions of base, like a percentage of salary, and the
+// terminal value received on terminalDate.
+//
+// The amounts of percents are the fractions, such as 0.05 for 5%, and each
+// is a contribution of base times it. Contributions are positive fractions,
+// which become negative payments like any investment.
+func ComputeFromPercents(base float64, percents []Payment, terminalValue float64, terminalDate time.Time) (float64, error) {
+	payments := make([]Payment, len(percents), len(percents)+1)
+	for i, p := range percents {
+		payments[i] = Payment{p.Date, -base * p.Amount}
+	}
+	return Compute(append(payments, Payment{terminalDate, terminalValue}))
+}
@@ -0,0 +1,31 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeFromPercents(t *testing.T) {
+	rate, err := ComputeFromPercents(60000, []Payment{
+		{parseDate("2020-01-31"), 0.05},
+		{parseDate("2020-02-29"), 0.05},
+		{parseDate("2020-03-31"), 0.08},
+	}, 10500, parseDate("2021-03-31"))
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+
+	expected, _ := Compute([]Payment{
+		{parseDate("2020-01-31"), -3000},
+		{parseDate("2020-02-29"), -3000},
+		{parseDate("2020-03-31"), -4800},
+		{parseDate("2021-03-31"), 10500},
+	})
+	if math.Abs(rate-expected) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, rate)
+	}
+}
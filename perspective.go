@@ -0,0 +1,28 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "math"
+
+// RatePerspectives calculates the internal rate of return of payments, which
+// is annualized, along with the absolute return it amounts to over the
+// holding period of years from the first payment to the last.
+//
+// The absolute return is (1 + annualized)^years - 1, so both coincide for a
+// holding period of exactly one year, while the absolute return is the
+// smaller of the two in magnitude for shorter periods and the larger for
+// longer ones. It is NaN when the annualized rate is.
+func RatePerspectives(payments []Payment) (annualized, absolute, years float64, err error) {
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if annualized, err = Compute(sorted); err != nil {
+		return 0, 0, 0, err
+	}
+
+	years = getExp(sorted[len(sorted)-1], sorted[0])
+	return annualized, math.Pow(1+annualized, years) - 1, years, nil
+}
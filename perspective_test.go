@@ -0,0 +1,46 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRatePerspectives(t *testing.T) {
+	for _, c := range []struct {
+		end      string
+		years    float64
+		absolute float64
+	}{
+		{"2021-07-02", 182.0 / 365, 0.1},
+		{"2022-01-01", 1, 0.1},
+		{"2024-01-01", 1095.0 / 365, 0.1},
+	} {
+		payments := []Payment{
+			{parseDate("2021-01-01"), -1000},
+			{parseDate(c.end), 1100},
+		}
+
+		annualized, absolute, years, err := RatePerspectives(payments)
+		if err != nil {
+			t.Fatal("Error computing rates:", err)
+		}
+		if years != c.years {
+			t.Errorf("Expected %g years, but was %g", c.years, years)
+		}
+		if math.Abs(absolute-c.absolute) >= maxError {
+			t.Errorf("Expected absolute return %.10f, but was %.10f", c.absolute, absolute)
+		}
+		expected := math.Pow(1+c.absolute, 1/c.years) - 1
+		if math.Abs(annualized-expected) >= maxError {
+			t.Errorf("Expected annualized rate %.10f, but was %.10f", expected, annualized)
+		}
+		if (years < 1 && annualized <= absolute) || (years > 1 && annualized >= absolute) {
+			t.Errorf("Unexpected annualized rate %.10f against absolute return %.10f over %g years",
+				annualized, absolute, years)
+		}
+	}
+}
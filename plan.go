@@ -0,0 +1,114 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+const (
+	maxPeriods = 1000
+	maxHorizon = 100 * 365 * 24 * time.Hour
+)
+
+// ErrTargetNotReached is returned by PeriodsToTarget when the target rate is
+// not reached within the maximum number of periods it attempts, and by
+// TargetDate when no date reaches it.
+var ErrTargetNotReached = errors.New("target rate not reached")
+
+// ErrInvalidInterval is returned when a non-positive interval is provided.
+var ErrInvalidInterval = errors.New("interval must be positive")
+
+// PeriodsToTarget calculates the number of additional periods of
+// contributions needed for the XIRR of payments to reach targetRate.
+//
+// For n periods, it appends futureContribution at each of the n dates
+// following the last payment, spaced interval apart, along with a terminal
+// value of terminalValueFunc(date) on the last of those dates. The terminal
+// value for zero periods is placed on the date of the last payment. The
+// contribution follows the sign convention of payments, so an investment
+// must be negative. It returns the smallest n for which the XIRR meets or
+// exceeds targetRate, trying up to 1000 periods spanning no more than 100
+// years before returning ErrTargetNotReached.
+func PeriodsToTarget(payments []Payment, futureContribution float64, interval time.Duration, targetRate float64, terminalValueFunc func(date time.Time) float64) (int, error) {
+	if interval <= 0 {
+		return 0, ErrInvalidInterval
+	}
+	if len(payments) == 0 {
+		return 0, ErrInvalidPayments
+	}
+
+	last := payments[0].Date
+	for _, p := range payments {
+		if p.Date.After(last) {
+			last = p.Date
+		}
+	}
+
+	series := make([]Payment, len(payments), len(payments)+maxPeriods+1)
+	copy(series, payments)
+	date := last
+	for n := 0; n <= maxPeriods; n++ {
+		if n > 0 {
+			date = date.Add(interval)
+			if date.Sub(last) > maxHorizon {
+				break
+			}
+			series = append(series, Payment{date, futureContribution})
+		}
+
+		rate, err := Compute(append(series, Payment{date, terminalValueFunc(date)}))
+		if err != nil && err != ErrInvalidPayments {
+			return 0, err
+		}
+		if err == nil && !math.IsNaN(rate) && rate >= targetRate {
+			return n, nil
+		}
+	}
+
+	return 0, ErrTargetNotReached
+}
+
+// TargetDate calculates the date on which terminalValue must be received,
+// after flows, for the XIRR to equal targetRate.
+//
+// The net present value of flows at targetRate, as of their first date, is
+// offset by the terminal value discounted over t years when
+// (1 + targetRate)^t = terminalValue / -npv, which is solved for t and
+// rounded to the nearest day. ErrTargetNotReached is returned when there is
+// no such t, as when the terminal value has the same sign as the net present
+// value, or when it falls before the last of flows. Like for Compute,
+// ErrInvalidPayments is returned unless flows and the terminal value include
+// both positive and negative payments.
+func TargetDate(flows []Payment, terminalValue, targetRate float64) (time.Time, error) {
+	if len(flows) == 0 {
+		return time.Time{}, ErrInvalidPayments
+	}
+
+	// The terminal value is validated along with flows on their last date,
+	// after which the stable sort keeps it.
+	last := flows[0].Date
+	for _, p := range flows {
+		if p.Date.After(last) {
+			last = p.Date
+		}
+	}
+	withTerminal := make([]Payment, len(flows), len(flows)+1)
+	copy(withTerminal, flows)
+	sorted, err := Canonical(append(withTerminal, Payment{last, terminalValue}), Options{})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	sorted = sorted[:len(sorted)-1]
+	s := newSeries(sorted)
+	years := math.Log(terminalValue/-xirr(s, targetRate, Options{})) / math.Log1p(targetRate)
+	if math.IsNaN(years) || math.IsInf(years, 0) || years < s.exps[len(s.exps)-1] {
+		return time.Time{}, ErrTargetNotReached
+	}
+	return sorted[0].Date.AddDate(0, 0, int(math.Round(years*365))), nil
+}
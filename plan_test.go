@@ -0,0 +1,85 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPeriodsToTarget(t *testing.T) {
+	start := parseDate("2016-01-01")
+	year := 365 * 24 * time.Hour
+
+	// The terminal value after n years yields a rate of exactly 5n%.
+	terminal := func(date time.Time) float64 {
+		n := math.Round(float64(date.Sub(start)) / float64(year))
+		return 100 * math.Pow(1+0.05*n, n)
+	}
+
+	payments := []Payment{
+		{start, -100},
+		{start.Add(year), 0},
+	}
+	n, err := PeriodsToTarget(payments, 0, year, 0.18, terminal)
+	if err != nil {
+		t.Fatal("Error computing periods:", err)
+	}
+	if n != 3 {
+		t.Errorf("Expected 3 periods, but was %d", n)
+	}
+
+	flat := func(time.Time) float64 { return 100 }
+	_, err = PeriodsToTarget(payments, 0, 5*year, 0.1, flat)
+	if err != ErrTargetNotReached {
+		t.Errorf("Invalid error for unreachable target: %v", err)
+	}
+}
+
+func TestTargetDate(t *testing.T) {
+	flows := []Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2021-06-15"), -500},
+	}
+	target := parseDate("2025-09-20")
+
+	// The terminal value that gives 8% on target.
+	s := newSeries([]Payment{flows[0], flows[1], {target, 0}})
+	terminalValue := -xirr(s, 0.08, Options{}) * math.Pow(1.08, s.exps[2])
+
+	date, err := TargetDate(flows, terminalValue, 0.08)
+	if err != nil {
+		t.Fatal("Error computing target date:", err)
+	}
+	if d := date.Sub(target); d < -24*time.Hour || d > 24*time.Hour {
+		t.Errorf("Expected %v, but was %v", target, date)
+	}
+
+	rate, err := Compute(append(flows, Payment{date, terminalValue}))
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(rate-0.08) >= 1e-3 {
+		t.Errorf("Expected rate near %.10f, but was %.10f", 0.08, rate)
+	}
+
+	if _, err := TargetDate(flows, 100, 0.08); err != ErrTargetNotReached {
+		t.Errorf("Expected %v, but was %v", ErrTargetNotReached, err)
+	}
+}
+
+func TestTargetDateInvalidPayments(t *testing.T) {
+	flows := []Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2021-06-15"), -500},
+	}
+	if _, err := TargetDate(flows, -100, 0.08); err != ErrInvalidPayments {
+		t.Errorf("Expected %v, but was %v", ErrInvalidPayments, err)
+	}
+	if _, err := TargetDate(nil, 100, 0.08); err != ErrInvalidPayments {
+		t.Errorf("Expected %v, but was %v", ErrInvalidPayments, err)
+	}
+}
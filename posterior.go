@@ -0,0 +1,52 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"errors"
+	"math"
+)
+
+// amountError is the standard deviation of each amount, relative to its
+// magnitude, assumed by ComputePosterior.
+const amountError = 0.01
+
+// ErrInvalidStdDev is returned by ComputePosterior when the standard deviation
+// of the prior is not positive.
+var ErrInvalidStdDev = errors.New("prior standard deviation must be positive")
+
+// ComputePosterior calculates an estimate of the rate of return of payments
+// that combines their internal rate of return with a Gaussian prior belief
+// about the rate, of mean priorMean and standard deviation priorStdDev.
+//
+// The internal rate of return is treated as a Gaussian observation whose
+// standard deviation is computed as in ComputeWithUncertainty, assuming each
+// amount has a standard deviation of 1% of its magnitude. This is a fixed
+// modelling assumption; callers who know the errors of their amounts should
+// combine the result of ComputeWithUncertainty with their prior instead. The
+// estimate is the mean of the posterior, the average of the rate and
+// priorMean weighted by their precisions, the reciprocals of their
+// variances. Annualizing makes the rate of a short series sensitive to its
+// amounts, so its estimate is pulled toward the prior, while that of a long
+// series stays close to its rate. The estimate is NaN when the rate is.
+// ErrInvalidStdDev is returned when priorStdDev is not positive.
+func ComputePosterior(payments []Payment, priorMean, priorStdDev float64) (float64, error) {
+	if !(priorStdDev > 0) {
+		return 0, ErrInvalidStdDev
+	}
+	stddevs := make([]float64, len(payments))
+	for i, p := range payments {
+		stddevs[i] = amountError * math.Abs(p.Amount)
+	}
+
+	rate, stddev, err := ComputeWithUncertainty(payments, stddevs)
+	if err != nil || math.IsNaN(rate) || stddev == 0 {
+		return rate, err
+	}
+
+	dataPrecision := 1 / (stddev * stddev)
+	priorPrecision := 1 / (priorStdDev * priorStdDev)
+	return (rate*dataPrecision + priorMean*priorPrecision) / (dataPrecision + priorPrecision), nil
+}
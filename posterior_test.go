@@ -0,0 +1,69 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputePosterior(t *testing.T) {
+	const priorMean, priorStdDev = 0.05, 0.05
+
+	for _, c := range []struct {
+		name     string
+		payments []Payment
+		pull     func(float64) bool
+	}{
+		{"short", []Payment{
+			{parseDate("2020-01-01"), -1000},
+			{parseDate("2020-01-31"), 1010},
+		}, func(pull float64) bool { return pull > 0.5 }},
+		{"long", []Payment{
+			{parseDate("2000-01-01"), -1000},
+			{parseDate("2020-01-01"), 4000},
+		}, func(pull float64) bool { return pull < 0.01 }},
+	} {
+		rate, err := Compute(c.payments)
+		if err != nil {
+			t.Fatal("Error computing XIRR:", err)
+		}
+		posterior, err := ComputePosterior(c.payments, priorMean, priorStdDev)
+		if err != nil {
+			t.Fatal("Error computing posterior:", err)
+		}
+
+		// The fraction of the way from the rate to the prior mean.
+		pull := (rate - posterior) / (rate - priorMean)
+		if !c.pull(pull) {
+			t.Errorf("Unexpected pull toward the prior of %.4f for the %s series", pull, c.name)
+		}
+	}
+}
+
+func TestComputePosteriorNaN(t *testing.T) {
+	posterior, err := ComputePosterior([]Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2020-01-01"), 1000},
+	}, 0.05, 0.05)
+	if err != nil {
+		t.Fatal("Error computing posterior:", err)
+	}
+	if !math.IsNaN(posterior) {
+		t.Errorf("Expected %.10f, but was %.10f", math.NaN(), posterior)
+	}
+}
+
+func TestComputePosteriorInvalidStdDev(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2021-01-01"), 1100},
+	}
+	for _, priorStdDev := range []float64{0, -0.05, math.NaN()} {
+		if _, err := ComputePosterior(payments, 0.05, priorStdDev); err != ErrInvalidStdDev {
+			t.Errorf("Expected %v for %v, but was %v", ErrInvalidStdDev, priorStdDev, err)
+		}
+	}
+}
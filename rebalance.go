@@ -0,0 +1,35 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "time"
+
+// RebalanceImpact calculates the internal rate of return of payments before
+// and after rebalanceDate, given the value of the holdings on it.
+//
+// The period before consists of the payments up to and including
+// rebalanceDate followed by a redemption of interimValue, while the period
+// after consists of an investment of interimValue on rebalanceDate followed
+// by the remaining payments. ErrInvalidInterval is returned unless
+// rebalanceDate falls strictly between the first and the last payments.
+func RebalanceImpact(payments []Payment, rebalanceDate time.Time, interimValue float64) (before, after float64, err error) {
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if !rebalanceDate.After(sorted[0].Date) || !rebalanceDate.Before(sorted[len(sorted)-1].Date) {
+		return 0, 0, ErrInvalidInterval
+	}
+
+	front, back := splitPayments(sorted, rebalanceDate, interimValue)
+	if before, err = Compute(front); err != nil {
+		return 0, 0, err
+	}
+	if after, err = Compute(back); err != nil {
+		return 0, 0, err
+	}
+	return before, after, nil
+}
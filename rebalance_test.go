@@ -0,0 +1,30 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRebalanceImpact(t *testing.T) {
+	// 10% a year before rebalancing and 5% a year after.
+	before, after, err := RebalanceImpact([]Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2017-06-11"), -500},
+		{parseDate("2018-06-11"), 200},
+		{parseDate("2020-06-10"), 1719.9},
+	}, parseDate("2018-06-11"), 1560)
+	if err != nil {
+		t.Fatal("Error computing rebalance impact:", err)
+	}
+
+	if math.Abs(before-0.1) >= maxError {
+		t.Errorf("Expected %.10f before, but was %.10f", 0.1, before)
+	}
+	if math.Abs(after-0.05) >= maxError {
+		t.Errorf("Expected %.10f after, but was %.10f", 0.05, after)
+	}
+}
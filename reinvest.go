@@ -0,0 +1,50 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"time"
+)
+
+// ComputeReinvestDistributions calculates the internal rate of return of an
+// investment made with purchases that pays distributions, with the
+// distributions either received as cash or reinvested.
+//
+// The terminal value, received on terminalDate, is the value of the holdings
+// bought with purchases alone. When reinvest is false, the distributions are
+// payments received on their dates, like an income share class. When it is
+// true, like an accumulation share class, they are assumed to grow at the
+// internal rate of return of purchases and the terminal value, the growth of
+// the holdings without distributions, and are added to the terminal value
+// instead. ErrInvalidInterval is returned when a distribution is made after
+// terminalDate.
+func ComputeReinvestDistributions(purchases, distributions []Payment, reinvest bool, terminalValue float64, terminalDate time.Time) (float64, error) {
+	payments := make([]Payment, len(purchases), len(purchases)+len(distributions)+1)
+	copy(payments, purchases)
+	for _, d := range distributions {
+		if d.Date.After(terminalDate) {
+			return 0, ErrInvalidInterval
+		}
+	}
+	terminal := Payment{terminalDate, terminalValue}
+
+	if !reinvest {
+		payments = append(payments, distributions...)
+		return Compute(append(payments, terminal))
+	}
+
+	growth, err := Compute(append(payments, terminal))
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(growth) {
+		return growth, nil
+	}
+	for _, d := range distributions {
+		terminal.Amount += d.Amount * math.Pow(1+growth, getExp(terminal, d))
+	}
+	return Compute(append(payments, terminal))
+}
@@ -0,0 +1,49 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeReinvestDistributions(t *testing.T) {
+	purchases := []Payment{{parseDate("2020-01-01"), -1000}}
+	distributions := []Payment{
+		{parseDate("2021-01-01"), 50},
+		{parseDate("2022-01-01"), 50},
+	}
+	terminalDate := parseDate("2023-01-01")
+
+	cash, err := ComputeReinvestDistributions(purchases, distributions, false, 1200, terminalDate)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	expected, err := Compute(append(append([]Payment(nil), purchases...),
+		distributions[0], distributions[1], Payment{terminalDate, 1200}))
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(cash-expected) > maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, cash)
+	}
+
+	reinvested, err := ComputeReinvestDistributions(purchases, distributions, true, 1200, terminalDate)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	growth := math.Pow(1.2, 365.0/1096) - 1
+	terminal := 1200 + 50*math.Pow(1+growth, 730.0/365) + 50*math.Pow(1+growth, 365.0/365)
+	expected = math.Pow(terminal/1000, 365.0/1096) - 1
+	if math.Abs(reinvested-expected) > maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, reinvested)
+	}
+
+	// Reinvesting at the growth of the holdings, which is below the rate
+	// earned with cash distributions, lowers the rate.
+	if reinvested >= cash {
+		t.Errorf("Expected reinvested rate below %.10f, but was %.10f", cash, reinvested)
+	}
+}
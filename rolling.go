@@ -0,0 +1,51 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"time"
+)
+
+// RollingRates calculates the internal rate of return over each window of the
+// given length, starting from the first payment and advancing by step until
+// the window would extend past the last payment.
+//
+// Each window is treated as an investment of valuation(start) at its start,
+// followed by the payments dated after its start up to and including its end,
+// and a redemption of valuation(end) at its end. So valuation must return the
+// value of the holdings at the end of a day, after the payments made on it.
+// The rate is NaN for windows whose payments are not solvable.
+func RollingRates(payments []Payment, window time.Duration, step time.Duration, valuation func(date time.Time) float64) ([]float64, error) {
+	if window <= 0 || step <= 0 {
+		return nil, ErrInvalidInterval
+	}
+	if len(payments) == 0 {
+		return nil, nil
+	}
+
+	sorted := normalize(payments, Options{})
+	last := sorted[len(sorted)-1].Date
+
+	var rates []float64
+	for start := sorted[0].Date; !start.Add(window).After(last); start = start.Add(step) {
+		end := start.Add(window)
+		series := []Payment{{start, -valuation(start)}}
+		for _, p := range sorted {
+			if p.Date.After(start) && !p.Date.After(end) {
+				series = append(series, p)
+			}
+		}
+		series = append(series, Payment{end, valuation(end)})
+
+		rate, err := Compute(series)
+		if err != nil {
+			rate = math.NaN()
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRollingRates(t *testing.T) {
+	day := 24 * time.Hour
+	start := parseDate("2016-06-11")
+	payments := []Payment{
+		{start, -1000},
+		{start.Add(730 * day), -500},
+		{start.Add(1825 * day), -200},
+	}
+
+	// Every payment grows at 10% a year.
+	valuation := func(date time.Time) float64 {
+		value := 0.0
+		for _, p := range payments {
+			if !p.Date.After(date) {
+				value -= p.Amount * math.Pow(1.1, getExp(Payment{Date: date}, p))
+			}
+		}
+		return value
+	}
+
+	rates, err := RollingRates(payments, 365*day, 73*day, valuation)
+	if err != nil {
+		t.Fatal("Error computing rolling rates:", err)
+	}
+	if len(rates) != 21 {
+		t.Fatalf("Expected 21 windows, but was %d", len(rates))
+	}
+	for i, rate := range rates {
+		if math.Abs(rate-0.1) >= maxError {
+			t.Errorf("Expected %.10f for window %d, but was %.10f", 0.1, i, rate)
+		}
+	}
+}
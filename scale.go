@@ -0,0 +1,27 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+// ScaleInvarianceCheck calculates the internal rate of return of payments and
+// of payments with every amount multiplied by factor.
+//
+// Scaling every amount scales the net present value at every rate by the same
+// factor, leaving the rate at which it is zero unchanged, so both rates are
+// expected to agree within the tolerance of the solver for any factor other
+// than zero. A difference between them points to a numerical problem.
+func ScaleInvarianceCheck(payments []Payment, factor float64) (original, scaled float64, err error) {
+	if original, err = Compute(payments); err != nil {
+		return 0, 0, err
+	}
+
+	scaledPayments := make([]Payment, len(payments))
+	for i, p := range payments {
+		scaledPayments[i] = Payment{p.Date, p.Amount * factor}
+	}
+	if scaled, err = Compute(scaledPayments); err != nil {
+		return 0, 0, err
+	}
+	return original, scaled, nil
+}
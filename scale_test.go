@@ -0,0 +1,39 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScaleInvarianceCheck(t *testing.T) {
+	for _, file := range []string{"single_redemption.csv", "random.csv"} {
+		payments, err := loadPayments(file)
+		if err != nil {
+			t.Fatal("Error loading input:", err)
+		}
+
+		for _, factor := range []float64{1e-200, 1e-12, 1e-3, 0.5, 3, 1e6, 1e12, 1e200, -1} {
+			original, scaled, err := ScaleInvarianceCheck(payments, factor)
+			if err != nil {
+				t.Fatal("Error computing XIRR:", err)
+			}
+			if math.Abs(original-scaled) >= maxError {
+				t.Errorf("Expected %.10f for %s scaled by %g, but was %.10f", original, file, factor, scaled)
+			}
+		}
+	}
+}
+
+func TestScaleInvarianceCheckZero(t *testing.T) {
+	payments, err := loadPayments("random.csv")
+	if err != nil {
+		t.Fatal("Error loading input:", err)
+	}
+	if _, _, err := ScaleInvarianceCheck(payments, 0); err != ErrInvalidPayments {
+		t.Errorf("Expected %v, but was %v", ErrInvalidPayments, err)
+	}
+}
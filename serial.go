@@ -0,0 +1,66 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrInvalidSerialDate is returned by FromSerialDates for serial numbers that
+// do not represent a valid date.
+var ErrInvalidSerialDate = errors.New("invalid serial date")
+
+// A SerialEpoch identifies the date system of spreadsheet serial dates.
+type SerialEpoch int
+
+const (
+	// Epoch1900 is the default date system of Excel and Lotus 1-2-3, where
+	// 1 is 1900-01-01. It treats 1900 as a leap year, so 60 stands for the
+	// non-existent 1900-02-29. Google Sheets agrees with it from 61, which
+	// is 1900-03-01.
+	Epoch1900 SerialEpoch = iota
+	// Epoch1904 is the date system of older Excel versions for Mac, where 0
+	// is 1904-01-01.
+	Epoch1904
+)
+
+// FromSerialDates converts spreadsheet serial dates in the given date system,
+// along with the corresponding amounts, into payments. The fractional part of
+// a serial date is taken as the time of day, in UTC.
+//
+// ErrInvalidSerialDate is returned for negative serial dates and, in
+// Epoch1900, for 60.
+func FromSerialDates(serials []float64, amounts []float64, epoch SerialEpoch) ([]Payment, error) {
+	if len(serials) != len(amounts) {
+		return nil, ErrMismatchedLengths
+	}
+
+	payments := make([]Payment, len(serials))
+	for i, serial := range serials {
+		if serial < 0 || math.IsNaN(serial) || math.IsInf(serial, 0) {
+			return nil, ErrInvalidSerialDate
+		}
+
+		var base time.Time
+		switch {
+		case epoch == Epoch1904:
+			base = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+		case serial >= 61:
+			base = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+		case serial >= 60:
+			return nil, ErrInvalidSerialDate
+		default:
+			base = time.Date(1899, 12, 31, 0, 0, 0, 0, time.UTC)
+		}
+
+		days := math.Floor(serial)
+		dayFraction := time.Duration(math.Round((serial - days) * float64(24*time.Hour)))
+		payments[i] = Payment{base.AddDate(0, 0, int(days)).Add(dayFraction), amounts[i]}
+	}
+
+	return payments, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromSerialDates(t *testing.T) {
+	cases := []struct {
+		epoch  SerialEpoch
+		serial float64
+		date   time.Time
+	}{
+		{Epoch1900, 1, parseDate("1900-01-01")},
+		{Epoch1900, 59, parseDate("1900-02-28")},
+		{Epoch1900, 61, parseDate("1900-03-01")},
+		{Epoch1900, 42532, parseDate("2016-06-11")},
+		{Epoch1900, 43262.5, parseDate("2018-06-11").Add(12 * time.Hour)},
+		{Epoch1904, 0, parseDate("1904-01-01")},
+		{Epoch1904, 41070, parseDate("2016-06-11")},
+		{Epoch1904, 41800.25, parseDate("2018-06-11").Add(6 * time.Hour)},
+	}
+
+	for _, c := range cases {
+		payments, err := FromSerialDates([]float64{c.serial}, []float64{100}, c.epoch)
+		if err != nil {
+			t.Fatal("Error converting serial dates:", err)
+		}
+		if !payments[0].Date.Equal(c.date) || payments[0].Amount != 100 {
+			t.Errorf("Expected %v for %v in epoch %d, but was %v", c.date, c.serial, c.epoch, payments[0])
+		}
+	}
+}
+
+func TestFromSerialDatesInvalid(t *testing.T) {
+	if _, err := FromSerialDates([]float64{60}, []float64{100}, Epoch1900); err != ErrInvalidSerialDate {
+		t.Errorf("Invalid error for 1900-02-29: %v", err)
+	}
+	if _, err := FromSerialDates([]float64{60}, []float64{100}, Epoch1904); err != nil {
+		t.Errorf("Error converting 60 in 1904 epoch: %v", err)
+	}
+	if _, err := FromSerialDates([]float64{-1}, []float64{100}, Epoch1904); err != ErrInvalidSerialDate {
+		t.Errorf("Invalid error for negative serial: %v", err)
+	}
+	if _, err := FromSerialDates([]float64{1, 2}, []float64{100}, Epoch1900); err != ErrMismatchedLengths {
+		t.Errorf("Invalid error for mismatched lengths: %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "math"
+
+// smoothingSteps is the number of terminal values, beyond the first, averaged
+// over by SmoothedRate.
+const smoothingSteps = 10
+
+// SmoothedRate calculates the average internal rate of return of payments
+// over a band of terminal values, the amount of the last payment, spread
+// evenly from reference to the terminal value itself. The reference is a
+// trusted value for the terminal value, such as the last audited valuation,
+// and the rate is NaN when any of the rates in the band are.
+//
+// It is a stability aid for terminal values that are possibly stale or
+// noisy marks. Since one end of the band is fixed at reference, a change in
+// the terminal value moves only part of the band, and the smoothed rate
+// moves about half as much as the plain rate. In exchange, the result is
+// pulled about halfway towards the rate the payments would have with a
+// terminal value of reference, so it equals their internal rate of return
+// only when the terminal value equals reference.
+func SmoothedRate(payments []Payment, reference float64) (float64, error) {
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, err
+	}
+
+	last := len(sorted) - 1
+	terminal := sorted[last].Amount
+	perturbed := make([]Payment, len(sorted))
+	copy(perturbed, sorted)
+
+	total := 0.0
+	for i := 0; i <= smoothingSteps; i++ {
+		perturbed[last].Amount = reference + (terminal-reference)*float64(i)/smoothingSteps
+		rate, err := Compute(perturbed)
+		if err != nil {
+			return 0, err
+		}
+		if math.IsNaN(rate) {
+			return rate, nil
+		}
+		total += rate
+	}
+	return total / (smoothingSteps + 1), nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSmoothedRate(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2020-07-01"), -500},
+		{parseDate("2023-01-01"), 2000},
+	}
+
+	plain, err := Compute(payments)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	smoothed, err := SmoothedRate(payments, 2000)
+	if err != nil {
+		t.Fatal("Error computing smoothed rate:", err)
+	}
+	if math.Abs(smoothed-plain) > maxError {
+		t.Errorf("Expected %.10f, but was %.10f", plain, smoothed)
+	}
+
+	// Jitter in the terminal value moves the smoothed rate less than the
+	// plain rate.
+	for _, jitter := range []float64{0.95, 0.99, 1.01, 1.05} {
+		jittered := append([]Payment(nil), payments...)
+		jittered[2].Amount *= jitter
+		plainJittered, err := Compute(jittered)
+		if err != nil {
+			t.Fatal("Error computing XIRR:", err)
+		}
+		smoothedJittered, err := SmoothedRate(jittered, 2000)
+		if err != nil {
+			t.Fatal("Error computing smoothed rate:", err)
+		}
+
+		plainShift := plainJittered - plain
+		smoothedShift := smoothedJittered - smoothed
+		if math.Abs(smoothedShift) >= math.Abs(plainShift) {
+			t.Errorf("Expected shift below %.10f for jitter %g, but was %.10f",
+				plainShift, jitter, smoothedShift)
+		}
+		if math.Abs(smoothedShift/plainShift-0.5) > 0.05 {
+			t.Errorf("Expected about half of shift %.10f for jitter %g, but was %.10f",
+				plainShift, jitter, smoothedShift)
+		}
+	}
+}
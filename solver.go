@@ -0,0 +1,23 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+// A Solver computes the internal rate of return of series of payments with
+// the same options. It is safe for concurrent use by multiple goroutines.
+type Solver struct {
+	opts Options
+}
+
+// NewSolver returns a Solver configured by opts, with the defaults of unset
+// options resolved once.
+func NewSolver(opts Options) *Solver {
+	return &Solver{opts.withDefaults()}
+}
+
+// Solve calculates the internal rate of return of payments like
+// ComputeVerbose, with the options of the Solver.
+func (s *Solver) Solve(payments []Payment) (Result, error) {
+	return ComputeVerbose(payments, s.opts)
+}
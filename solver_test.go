@@ -0,0 +1,50 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"sync"
+	"testing"
+)
+
+func TestSolver(t *testing.T) {
+	files := []string{"single_redemption.csv", "random.csv"}
+	rates := []float64{0.1361695793742, 0.6924974337277}
+
+	var series [][]Payment
+	for _, file := range files {
+		payments, err := loadPayments(file)
+		if err != nil {
+			t.Fatal("Error loading input:", err)
+		}
+		series = append(series, payments)
+	}
+
+	s := NewSolver(Options{CompensatedSum: true, Tolerance: 1e-12})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i, payments := range series {
+				res, err := s.Solve(payments)
+				if err != nil {
+					t.Error("Error computing XIRR:", err)
+					return
+				}
+				if math.Abs(res.Rate-rates[i]) >= maxError {
+					t.Errorf("Expected %.10f, but was %.10f", rates[i], res.Rate)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	_, err := s.Solve([]Payment{{parseDate("2016-06-11"), -100}})
+	if err != ErrInvalidPayments {
+		t.Errorf("Invalid error for negative payments: %v", err)
+	}
+}
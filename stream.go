@@ -0,0 +1,67 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// ComputeStream computes the XIRR of each series of payments received from in
+// and sends a Result for it to out. The Index of a Result is the position of
+// its series in the order received from in.
+//
+// Up to GOMAXPROCS series are computed concurrently, so results may be sent
+// out of order. ComputeStream blocks until in is closed or ctx is done, after
+// which it waits for series being computed and closes out. Series not yet
+// computed when ctx is done are discarded.
+func ComputeStream(ctx context.Context, in <-chan []Payment, out chan<- Result) {
+	type job struct {
+		index    int
+		payments []Payment
+	}
+
+	jobs := make(chan job)
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.GOMAXPROCS(0); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				res, _ := ComputeVerbose(j.payments, Options{})
+				res.Index = j.index
+				select {
+				case out <- res:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	index := 0
+loop:
+	for {
+		select {
+		case payments, ok := <-in:
+			if !ok {
+				break loop
+			}
+			select {
+			case jobs <- job{index, payments}:
+				index++
+			case <-ctx.Done():
+				break loop
+			}
+		case <-ctx.Done():
+			break loop
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+	close(out)
+}
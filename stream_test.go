@@ -0,0 +1,66 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestComputeStream(t *testing.T) {
+	files := []string{"single_redemption.csv", "random.csv", "single_redemption.csv"}
+	rates := []float64{0.1361695793742, 0.6924974337277, 0.1361695793742}
+
+	in := make(chan []Payment)
+	out := make(chan Result)
+	go ComputeStream(context.Background(), in, out)
+
+	go func() {
+		defer close(in)
+		for _, file := range files {
+			payments, err := loadPayments(file)
+			if err != nil {
+				panic(err)
+			}
+			in <- payments
+		}
+		in <- []Payment{{parseDate("2016-06-11"), -100}}
+	}()
+
+	seen := make(map[int]bool)
+	for res := range out {
+		seen[res.Index] = true
+		if res.Index == len(files) {
+			if res.Err != ErrInvalidPayments {
+				t.Errorf("Invalid error for series %d: %v", res.Index, res.Err)
+			}
+			continue
+		}
+
+		if res.Err != nil {
+			t.Fatalf("Error computing XIRR of series %d: %v", res.Index, res.Err)
+		}
+		if math.Abs(res.Rate-rates[res.Index]) >= maxError {
+			t.Errorf("Expected %.10f for series %d, but was %.10f", rates[res.Index], res.Index, res.Rate)
+		}
+	}
+
+	if len(seen) != len(files)+1 {
+		t.Errorf("Expected %d results, but was %d", len(files)+1, len(seen))
+	}
+}
+
+func TestComputeStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan []Payment)
+	out := make(chan Result)
+	cancel()
+
+	ComputeStream(ctx, in, out)
+	if _, ok := <-out; ok {
+		t.Error("Expected out to be closed")
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+// ComputeAfterTax calculates the internal rate of return of payments after
+// reducing every positive payment by taxRate.
+//
+// This is a simplified model that taxes all positive payments uniformly,
+// regardless of how much of them is gain or return of capital, so it
+// understates the after-tax return of payments that return capital.
+func ComputeAfterTax(payments []Payment, taxRate float64) (float64, error) {
+	taxed := make([]Payment, len(payments))
+	for i, p := range payments {
+		taxed[i] = p
+		if p.Amount > 0 {
+			taxed[i].Amount *= 1 - taxRate
+		}
+	}
+	return Compute(taxed)
+}
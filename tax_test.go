@@ -0,0 +1,42 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeAfterTax(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2016-12-10"), 40},
+		{parseDate("2017-06-11"), -500},
+		{parseDate("2018-06-11"), 1800},
+	}
+
+	pretax, err := Compute(payments)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	rate, err := ComputeAfterTax(payments, 0.1)
+	if err != nil {
+		t.Fatal("Error computing after-tax XIRR:", err)
+	}
+	if rate >= pretax || rate <= pretax-0.1 {
+		t.Errorf("Expected after-tax rate a little below %.10f, but was %.10f", pretax, rate)
+	}
+
+	rate, err = ComputeAfterTax([]Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2017-06-11"), 1200},
+	}, 0.25)
+	if err != nil {
+		t.Fatal("Error computing after-tax XIRR:", err)
+	}
+	if math.Abs(rate+0.1) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", -0.1, rate)
+	}
+}
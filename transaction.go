@@ -0,0 +1,40 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "time"
+
+// AverageTransactionReturn calculates the equal-weighted average of the
+// annualized returns of contributions, each treated as a separate investment
+// held until terminalDate.
+//
+// Contributions are negative payments, like investments elsewhere. The
+// allocator returns the fraction of terminalValue that is attributable to a
+// contribution, such as its share of the total contributed, and the fractions
+// are expected to sum to 1. ErrInvalidPayments is returned when there are no
+// contributions or any of them is not negative, and ErrInvalidInterval when
+// any is not made before terminalDate.
+func AverageTransactionReturn(contributions []Payment, terminalValue float64, terminalDate time.Time, allocator func(Payment) float64) (float64, error) {
+	if len(contributions) == 0 {
+		return 0, ErrInvalidPayments
+	}
+
+	total := 0.0
+	for _, c := range contributions {
+		if c.Amount >= 0 {
+			return 0, ErrInvalidPayments
+		}
+		if !c.Date.Before(terminalDate) {
+			return 0, ErrInvalidInterval
+		}
+
+		rate, err := Compute([]Payment{c, {terminalDate, allocator(c) * terminalValue}})
+		if err != nil {
+			return 0, err
+		}
+		total += rate
+	}
+	return total / float64(len(contributions)), nil
+}
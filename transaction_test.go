@@ -0,0 +1,44 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAverageTransactionReturn(t *testing.T) {
+	contributions := []Payment{
+		{parseDate("2019-01-01"), -1000},
+		{parseDate("2020-01-01"), -3000},
+	}
+	proportional := func(p Payment) float64 { return p.Amount / -4000 }
+
+	// Each contribution doubles by 2021-01-01.
+	rate, err := AverageTransactionReturn(contributions, 8000, parseDate("2021-01-01"), proportional)
+	if err != nil {
+		t.Fatal("Error computing average return:", err)
+	}
+	first := math.Pow(2, 365.0/731) - 1
+	second := math.Pow(2, 365.0/366) - 1
+	expected := (first + second) / 2
+	if math.Abs(rate-expected) > maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, rate)
+	}
+}
+
+func TestAverageTransactionReturnInvalid(t *testing.T) {
+	_, err := AverageTransactionReturn([]Payment{
+		{parseDate("2021-01-01"), -1000},
+	}, 2000, parseDate("2021-01-01"), func(Payment) float64 { return 1 })
+	if err != ErrInvalidInterval {
+		t.Errorf("Expected %v, but was %v", ErrInvalidInterval, err)
+	}
+
+	_, err = AverageTransactionReturn(nil, 2000, parseDate("2021-01-01"), nil)
+	if err != ErrInvalidPayments {
+		t.Errorf("Expected %v, but was %v", ErrInvalidPayments, err)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ErrNegativeTrim is returned by TrimmedRate when the number of payments to
+// trim is negative.
+var ErrNegativeTrim = errors.New("trim must not be negative")
+
+// TrimmedRate calculates the internal rate of return of payments after
+// removing the trim largest payments of each sign, which makes it robust to
+// a few erroneous spikes.
+//
+// At least one payment of each sign is always kept, so fewer than trim
+// payments are removed from a sign that does not have more than trim of them.
+func TrimmedRate(payments []Payment, trim int) (float64, error) {
+	if trim < 0 {
+		return 0, ErrNegativeTrim
+	}
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return 0, err
+	}
+
+	var positive, negative []int
+	for i, p := range sorted {
+		if p.Amount > 0 {
+			positive = append(positive, i)
+		} else if p.Amount < 0 {
+			negative = append(negative, i)
+		}
+	}
+
+	var removed []int
+	for _, indices := range [][]int{positive, negative} {
+		sort.Slice(indices, func(i, j int) bool {
+			return math.Abs(sorted[indices[i]].Amount) > math.Abs(sorted[indices[j]].Amount)
+		})
+		n := trim
+		if n > len(indices)-1 {
+			n = len(indices) - 1
+		}
+		removed = append(removed, indices[:n]...)
+	}
+	sort.Ints(removed)
+
+	return Compute(withoutIndices(sorted, removed))
+}
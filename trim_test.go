@@ -0,0 +1,56 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTrimmedRate(t *testing.T) {
+	// Rising monthly investments growing at 10% a year, with a spurious
+	// spike.
+	start, end := parseDate("2010-01-01"), parseDate("2020-01-01")
+	var payments []Payment
+	value := 0.0
+	for i := 0; i < 120; i++ {
+		date := start.AddDate(0, i, 0)
+		amount := 100 + float64(i)
+		payments = append(payments, Payment{date, -amount})
+		value += amount * math.Pow(1.1, getExp(Payment{Date: end}, Payment{Date: date}))
+	}
+	payments = append(payments,
+		Payment{end, value},
+		Payment{parseDate("2015-03-01"), 100000},
+	)
+
+	untrimmed, err := Compute(payments)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(untrimmed-0.1) < 0.1 {
+		t.Fatalf("Expected spike to distort the rate, but was %.10f", untrimmed)
+	}
+
+	// The largest negative payment removed is the last investment, which
+	// barely changes the rate.
+	rate, err := TrimmedRate(payments, 1)
+	if err != nil {
+		t.Fatal("Error computing trimmed XIRR:", err)
+	}
+	if math.Abs(rate-0.1) >= 0.01 {
+		t.Errorf("Expected about %.10f, but was %.10f", 0.1, rate)
+	}
+}
+
+func TestTrimmedRateNegativeTrim(t *testing.T) {
+	payments, err := loadPayments("random.csv")
+	if err != nil {
+		t.Fatal("Error loading input:", err)
+	}
+	if _, err := TrimmedRate(payments, -1); err != ErrNegativeTrim {
+		t.Errorf("Expected %v, but was %v", ErrNegativeTrim, err)
+	}
+}
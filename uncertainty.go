@@ -0,0 +1,59 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "math"
+
+// ComputeWithUncertainty calculates the internal rate of return of payments
+// along with its standard deviation, given the standard deviation of each
+// payment's amount in stddevs.
+//
+// The amounts are assumed to be independent, and their variances are
+// propagated linearly through the sensitivity of the rate to each amount.
+// Since the net present value is zero at the rate, the implicit function
+// theorem gives the sensitivity to the amount of payment i as
+// -v_i / NPV'(rate), where v_i is its discount factor. The standard deviation
+// is NaN when the rate is.
+func ComputeWithUncertainty(payments []Payment, stddevs []float64) (rate float64, rateStdDev float64, err error) {
+	if len(payments) != len(stddevs) {
+		return 0, 0, ErrMismatchedLengths
+	}
+
+	rate, err = Compute(payments)
+	if err != nil {
+		return 0, 0, err
+	}
+	if math.IsNaN(rate) {
+		return rate, math.NaN(), nil
+	}
+
+	sensitivities, err := rateSensitivities(payments, rate)
+	if err != nil {
+		return 0, 0, err
+	}
+	variance := 0.0
+	for i, s := range sensitivities {
+		variance += s * s * stddevs[i] * stddevs[i]
+	}
+	return rate, math.Sqrt(variance), nil
+}
+
+// rateSensitivities returns the partial derivative of the rate with respect
+// to the amount of each payment, in the order of payments, given the rate
+// solved for them.
+func rateSensitivities(payments []Payment, rate float64) ([]float64, error) {
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	first := sorted[0]
+	d := dxirr(newSeries(sorted), rate, Options{})
+	result := make([]float64, len(payments))
+	for i, p := range payments {
+		result[i] = -math.Pow(1.0+rate, -getExp(p, first)) / d
+	}
+	return result, nil
+}
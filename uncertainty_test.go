@@ -0,0 +1,63 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestComputeWithUncertainty(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2017-01-20"), -500},
+		{parseDate("2017-09-02"), 200},
+		{parseDate("2018-06-11"), 1600},
+	}
+	stddevs := []float64{0, 10, 5, 40}
+
+	rate, stddev, err := ComputeWithUncertainty(payments, stddevs)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+
+	expected, _ := Compute(payments)
+	if rate != expected {
+		t.Errorf("Expected %.10f, but was %.10f", expected, rate)
+	}
+
+	const samples = 20000
+	rnd := rand.New(rand.NewSource(1))
+	perturbed := make([]Payment, len(payments))
+	mean, sq := 0.0, 0.0
+	for i := 0; i < samples; i++ {
+		for j, p := range payments {
+			perturbed[j] = Payment{p.Date, p.Amount + rnd.NormFloat64()*stddevs[j]}
+		}
+		r, err := Compute(perturbed)
+		if err != nil {
+			t.Fatal("Error computing XIRR:", err)
+		}
+		mean += r
+		sq += r * r
+	}
+	mean /= samples
+	mcStdDev := math.Sqrt(sq/samples - mean*mean)
+
+	if math.Abs(stddev-mcStdDev)/mcStdDev >= 0.05 {
+		t.Errorf("Expected standard deviation close to %.10f, but was %.10f", mcStdDev, stddev)
+	}
+}
+
+func TestComputeWithUncertaintyLengths(t *testing.T) {
+	_, _, err := ComputeWithUncertainty([]Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2018-06-11"), 1600},
+	}, []float64{1})
+	if err != ErrMismatchedLengths {
+		t.Errorf("Invalid error for mismatched lengths: %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import "time"
+
+// A UnitPayment represents units of a fund purchased or redeemed at a
+// particular NAV on a particular date. Purchases have positive Units and
+// redemptions negative.
+type UnitPayment struct {
+	Date  time.Time
+	Units float64
+	NAV   float64
+}
+
+// ComputeUnits calculates the internal rate of return of purchases and
+// redemptions of fund units.
+//
+// Each of them is converted to a payment of Units * NAV, made for purchases
+// and received for redemptions. The units still held are valued at
+// currentNAV and treated as received on asOf.
+func ComputeUnits(payments []UnitPayment, currentNAV float64, asOf time.Time) (float64, error) {
+	cash := make([]Payment, len(payments), len(payments)+1)
+	held := 0.0
+	for i, p := range payments {
+		cash[i] = Payment{p.Date, -p.Units * p.NAV}
+		held += p.Units
+	}
+	return Compute(append(cash, Payment{asOf, held * currentNAV}))
+}
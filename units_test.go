@@ -0,0 +1,34 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComputeUnits(t *testing.T) {
+	rate, err := ComputeUnits([]UnitPayment{
+		{parseDate("2016-06-11"), 100, 10},
+		{parseDate("2017-01-05"), 50, 12},
+		{parseDate("2017-09-20"), -30, 11.5},
+	}, 13.25, parseDate("2018-06-11"))
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+
+	expected, err := Compute([]Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2017-01-05"), -600},
+		{parseDate("2017-09-20"), 345},
+		{parseDate("2018-06-11"), 1590},
+	})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(rate-expected) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, rate)
+	}
+}
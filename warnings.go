@@ -0,0 +1,57 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// dustRatio is the fraction of the largest amount below which a payment
+	// is considered negligible.
+	dustRatio = 1e-9
+	// shortPeriod is the holding period below which annualization makes the
+	// rate unreliable.
+	shortPeriod = 30 * 24 * time.Hour
+	// shallowRatio is the fraction of the total amount below which the
+	// change in net present value per unit change in rate is considered
+	// too small to pin down the rate.
+	shallowRatio = 1e-3
+)
+
+// warnings returns descriptions of soft issues with payments sorted by date
+// and their series s, whose rate has been computed.
+func warnings(sorted []Payment, s series, rate float64) []string {
+	var result []string
+
+	largest, total := 0.0, 0.0
+	for _, p := range sorted {
+		largest = math.Max(largest, math.Abs(p.Amount))
+		total += math.Abs(p.Amount)
+	}
+	for _, p := range sorted {
+		if p.Amount != 0 && math.Abs(p.Amount) < dustRatio*largest {
+			result = append(result, fmt.Sprintf("negligible payment of %g on %s", p.Amount, p.Date.Format("2006-01-02")))
+		}
+	}
+
+	for i := 1; i < len(sorted); i++ {
+		if s.exps[i] == s.exps[i-1] && (i == 1 || s.exps[i-1] != s.exps[i-2]) {
+			result = append(result, fmt.Sprintf("multiple payments on %s", sorted[i].Date.Format("2006-01-02")))
+		}
+	}
+
+	if span := sorted[len(sorted)-1].Date.Sub(sorted[0].Date); span < shortPeriod {
+		result = append(result, fmt.Sprintf("short holding period of %s", span))
+	}
+
+	if !math.IsNaN(rate) && !math.IsInf(rate, 0) && math.Abs(dxirr(s, rate, Options{})) < shallowRatio*total {
+		result = append(result, fmt.Sprintf("net present value barely changes around the rate of %g", rate))
+	}
+
+	return result
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"time"
+)
+
+const maxWorstCaseAmounts = 16
+
+// WorstCaseRate calculates the lowest internal rate of return of amounts,
+// when each of them can be paid on any date within the corresponding range in
+// dateRanges, inclusive of both ends.
+//
+// Dating payments at the ends of their ranges leads to the extreme rates, so
+// only the ends are tried, evaluating every combination of them. To bound the
+// search to 65536 combinations, at most 16 amounts are accepted, beyond which
+// ErrTooManyPayments is returned. ErrNotConverged is returned if none of the
+// combinations can be solved.
+func WorstCaseRate(amounts []float64, dateRanges [][2]time.Time) (float64, error) {
+	if len(amounts) != len(dateRanges) {
+		return 0, ErrMismatchedLengths
+	}
+	if len(amounts) > maxWorstCaseAmounts {
+		return 0, ErrTooManyPayments
+	}
+	for _, r := range dateRanges {
+		if r[0].After(r[1]) {
+			return 0, ErrInvalidInterval
+		}
+	}
+
+	payments := make([]Payment, len(amounts))
+	for i, a := range amounts {
+		payments[i].Amount = a
+	}
+	if err := validatePayments(payments); err != nil {
+		return 0, err
+	}
+
+	worst := math.NaN()
+	for mask := 0; mask < 1<<len(amounts); mask++ {
+		for i := range payments {
+			payments[i].Date = dateRanges[i][(mask>>i)&1]
+		}
+
+		rate, _ := Compute(payments)
+		if !math.IsNaN(rate) && (math.IsNaN(worst) || rate < worst) {
+			worst = rate
+		}
+	}
+
+	if math.IsNaN(worst) {
+		return 0, ErrNotConverged
+	}
+	return worst, nil
+}
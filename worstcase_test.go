@@ -0,0 +1,45 @@
+// Copyright 2018 Chandra Sekar S
+// Use of this source code is governed by the license
+// that can be found in the LICENSE file.
+
+package xirr
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestWorstCaseRate(t *testing.T) {
+	// The worst case invests as early and redeems as late as possible.
+	rate, err := WorstCaseRate([]float64{-1000, 1100}, [][2]time.Time{
+		{parseDate("2016-01-01"), parseDate("2016-03-01")},
+		{parseDate("2016-12-01"), parseDate("2017-12-31")},
+	})
+	if err != nil {
+		t.Fatal("Error computing worst case rate:", err)
+	}
+
+	expected, _ := Compute([]Payment{
+		{parseDate("2016-01-01"), -1000},
+		{parseDate("2017-12-31"), 1100},
+	})
+	if math.Abs(rate-expected) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, rate)
+	}
+}
+
+func TestWorstCaseRateInvalid(t *testing.T) {
+	_, err := WorstCaseRate([]float64{-1000, 1100}, [][2]time.Time{
+		{parseDate("2016-03-01"), parseDate("2016-01-01")},
+		{parseDate("2016-12-01"), parseDate("2017-12-31")},
+	})
+	if err != ErrInvalidInterval {
+		t.Errorf("Invalid error for reversed range: %v", err)
+	}
+
+	_, err = WorstCaseRate(make([]float64, 17), make([][2]time.Time, 17))
+	if err != ErrTooManyPayments {
+		t.Errorf("Invalid error for too many amounts: %v", err)
+	}
+}
@@ -4,6 +4,13 @@
 
 // Package xirr implements the XIRR function found in spreadsheet applications like
 // LibreOffice Calc.
+//
+// Slices passed to the functions in this package are never modified, nor is
+// the rest of their backing arrays, so they may be shared between calls.
+//
+// Only ComputeStream starts goroutines. All other functions do their work on
+// the calling goroutine, so they are suitable for hosts without threads, like
+// some WebAssembly runtimes.
 package xirr
 
 import (
@@ -16,42 +23,475 @@ import (
 const (
 	maxError = 1e-10
 	maxIter  = 50
+
+	// coarseBin is the span, in years, of the payments combined into one
+	// for the first pass of iterations enabled by CoarseTolerance.
+	coarseBin = 0.25
 )
 
 // ErrInvalidPayments is returned by Compute calls when both positive and
 // negative payments are not provided.
 var ErrInvalidPayments = errors.New("negative and positive payments are required")
 
+// ErrNotConverged is returned by functions that cannot represent a NaN rate
+// when the computation fails to converge.
+var ErrNotConverged = errors.New("rate computation did not converge")
+
+// ErrHoldingTooShort is returned by ComputeWithOptions when the payments span
+// less than the minimum holding period.
+var ErrHoldingTooShort = errors.New("holding period is too short")
+
+// ErrTooManyPayments is returned when more payments are provided than
+// allowed, like by ComputeWithOptions when there are more than MaxPayments.
+var ErrTooManyPayments = errors.New("too many payments")
+
+// ErrMismatchedLengths is returned when slices that must correspond to each
+// other element by element have different lengths.
+var ErrMismatchedLengths = errors.New("slices must have the same length")
+
+// ErrInvalidDaysPerYear is returned by ComputeWithOptions when DaysPerYear is
+// negative.
+var ErrInvalidDaysPerYear = errors.New("days per year must be positive")
+
 // A Payment represents a payment made or received on a particular date.
 type Payment struct {
 	Date   time.Time
 	Amount float64
 }
 
+// A Result represents the outcome of computing the XIRR of a series of
+// payments. Index identifies the series among those provided to a call that
+// computes several of them.
+type Result struct {
+	Index int
+	Rate  float64
+
+	// Iterations is the total number of iterations performed across all the
+	// guesses tried.
+	Iterations int
+
+	Diagnostics Diagnostics
+	Err         error
+}
+
+// Diagnostics provides details on how a rate was computed.
+type Diagnostics struct {
+	// Guess is the initial guess from which the rate was solved, expressed
+	// per year of Options.CompoundingBasis like the rate. It is NaN when no
+	// guess was needed or none led to a solution.
+	Guess float64
+
+	// IterationHistory holds the successive estimates of the rate, from
+	// Guess to the rate itself, when Options.RecordHistory is set.
+	IterationHistory []float64
+
+	// Warnings describes issues with the payments that did not prevent
+	// computing the rate, but may make it less meaningful.
+	Warnings []string
+}
+
+// A RootFinder finds a root of f, whose derivative is df, starting from
+// guess. It returns false if it could not find one.
+type RootFinder func(f, df func(float64) float64, guess float64) (float64, bool)
+
+// Options configures the computation performed by ComputeWithOptions. The
+// zero value computes the same rate as Compute.
+type Options struct {
+	// CompensatedSum enables Kahan summation when accumulating the net
+	// present value and its derivative. It reduces the rounding error on
+	// series with many payments at a small cost in speed.
+	CompensatedSum bool
+
+	// MinHoldingPeriod is the minimum span between the first and the last
+	// payment, below which ErrHoldingTooShort is returned instead of a rate
+	// that is unreliable after annualization. It is not checked when zero.
+	MinHoldingPeriod time.Duration
+
+	// Tolerance is the change in rate between successive iterations below
+	// which the rate is considered solved. It defaults to 1e-10 when zero.
+	Tolerance float64
+
+	// CoarseTolerance, when larger than Tolerance, enables a first pass of
+	// iterations from each guess that stops at this looser tolerance. The
+	// first pass solves an approximation of the payments, combined into one
+	// per quarter of a year from the first, so its iterations are cheaper
+	// on series with many payments. The rate is then refined to Tolerance
+	// on the payments themselves, starting from where the first pass
+	// stopped. Both passes share the same limit on the number of
+	// iterations.
+	CoarseTolerance float64
+
+	// MaxPayments is the maximum number of payments accepted, beyond which
+	// ErrTooManyPayments is returned before doing any work. It guards
+	// services against very large inputs and is not checked when zero.
+	MaxPayments int
+
+	// RecordHistory enables recording the successive estimates of the rate
+	// in the Diagnostics returned by ComputeVerbose.
+	RecordHistory bool
+
+	// RootFinder, when not nil, replaces Newton's method for finding the
+	// rate at which the net present value of payments is zero. It is called
+	// with the same guesses Newton's method would be, until it succeeds.
+	// The options controlling Newton's method do not apply to it, and
+	// iterations are not tracked.
+	RootFinder RootFinder
+
+	// AggregateSameDay enables combining the payments made on the same
+	// calendar day into a single payment of their net amount, dated as the
+	// earliest of them.
+	AggregateSameDay bool
+
+	// MidPeriodFlows enables treating each payment other than the first and
+	// the last as made halfway between the previous payment date and its
+	// own, as assumed by some performance standards for series valued
+	// infrequently.
+	MidPeriodFlows bool
+
+	// DayRounding selects how the time between payments is discretized
+	// into days before being divided by the length of a year. It defaults
+	// to DayFloor, counting whole days.
+	DayRounding DayRounding
+
+	// DayCount is the convention by which the time between payments is
+	// measured in years for discounting. It defaults to Actual365.
+	DayCount DayCount
+
+	// CompoundingBasis is the convention whose year the rate is expressed
+	// per, such as Actual365 for an effective annual rate over 365 days. It
+	// defaults to Actual365, and only the length of its year is used. The
+	// rate is converted from the years of DayCount, so for conventions that
+	// count actual days, it depends only on the compounding basis.
+	CompoundingBasis DayCount
+
+	// DaysPerYear, when positive, is the number of days in a year, like 366
+	// for a leap year or 250 for trading days. It replaces the length of
+	// the year of both DayCount and CompoundingBasis, so the rate is per
+	// DaysPerYear days. It is not used when zero, and ErrInvalidDaysPerYear
+	// is returned when negative.
+	DaysPerYear float64
+}
+
+// DayRounding is a way of discretizing the time between payments into days.
+type DayRounding int
+
+// Supported ways of discretizing time into days. With DayExact, fractions of
+// days are kept.
+const (
+	DayFloor DayRounding = iota
+	DayRound
+	DayCeil
+	DayExact
+)
+
+// DayCount is a convention for measuring the time between dates in years.
+type DayCount int
+
+// Supported day count conventions. Actual365, Actual360 and Actual36525
+// divide the actual days between dates by 365, 360 and 365.25 respectively.
+// Thirty360 counts every month as 30 days, by the US bond basis, and divides
+// by 360. It ignores the time of day, so DayRounding does not apply to it.
+const (
+	Actual365 DayCount = iota
+	Actual360
+	Actual36525
+	Thirty360
+)
+
+// yearDays returns the number of days in a year by d.
+func (d DayCount) yearDays() float64 {
+	switch d {
+	case Actual360, Thirty360:
+		return 360
+	case Actual36525:
+		return 365.25
+	}
+	return 365
+}
+
+// yearDays returns the number of days in a year for discounting and for
+// compounding by o.
+func (o Options) yearDays() (discounting, compounding float64) {
+	if o.DaysPerYear > 0 {
+		return o.DaysPerYear, o.DaysPerYear
+	}
+	return o.DayCount.yearDays(), o.CompoundingBasis.yearDays()
+}
+
+// withDefaults returns o with the defaults of unset fields filled in.
+func (o Options) withDefaults() Options {
+	if o.Tolerance == 0 {
+		o.Tolerance = maxError
+	}
+	return o
+}
+
 // Compute calculates the internal rate of return of a series of irregular
 // payments.
 //
 // It tries to identify the rate of return using Newton's method with an
 // initial guess of 0.1. If that does not provide a solution, it attempts with
 // guesses from -0.99 to 0.99 in increments of 0.01 and returns NaN if that
-// fails too.
+// fails too. A series of exactly two payments on different days has the
+// closed form solution (-a1/a0)^(1/t) - 1, which is used directly instead.
+// Newton steps that would take the rate to -1 or below are shortened to stay
+// within the domain where the rate is defined.
+//
+// The order of signs is not significant, so series starting with an inflow,
+// like a short position, are handled the same as those starting with an
+// outflow.
 func Compute(payments []Payment) (xirr float64, err error) {
-	if err := validatePayments(payments); err != nil {
-		return 0, err
+	return ComputeWithOptions(payments, Options{})
+}
+
+// ComputeWithOptions calculates the internal rate of return of a series of
+// irregular payments like Compute, configured by opts.
+func ComputeWithOptions(payments []Payment, opts Options) (xirr float64, err error) {
+	res, err := compute(payments, opts, false)
+	return res.Rate, err
+}
+
+// ComputeVerbose calculates the internal rate of return of a series of
+// irregular payments like ComputeWithOptions, and returns it as a Result
+// along with details on how it was computed. The error, if any, is also set
+// as Err in the Result.
+func ComputeVerbose(payments []Payment, opts Options) (Result, error) {
+	return compute(payments, opts, true)
+}
+
+// compute calculates the internal rate of return of payments, collecting
+// warnings in the Diagnostics of the Result only when withWarnings is set.
+func compute(payments []Payment, opts Options, withWarnings bool) (Result, error) {
+	sorted, err := Canonical(payments, opts)
+	if err != nil {
+		return Result{Err: err}, err
+	}
+
+	s := newSeriesWithOptions(sorted, opts)
+	res := solve(s, opts)
+	if withWarnings {
+		res.Diagnostics.Warnings = warnings(sorted, s, res.Rate)
+	}
+	if from, to := opts.yearDays(); from != to {
+		rebase := func(rate float64) float64 { return math.Pow(1+rate, to/from) - 1 }
+		res.Rate = rebase(res.Rate)
+		res.Diagnostics.Guess = rebase(res.Diagnostics.Guess)
+		for i, r := range res.Diagnostics.IterationHistory {
+			res.Diagnostics.IterationHistory[i] = rebase(r)
+		}
+	}
+	return res, nil
+}
+
+// Canonical returns payments in the form they are solved in by
+// ComputeWithOptions. They are sorted by date, with monotonic clock readings
+// stripped from the dates, with payments on the same day combined when
+// opts.AggregateSameDay is set and with interior payments moved to the middle
+// of their period when opts.MidPeriodFlows is set. The same errors returned by
+// ComputeWithOptions for invalid payments are returned.
+func Canonical(payments []Payment, opts Options) ([]Payment, error) {
+	if opts.MaxPayments > 0 && len(payments) > opts.MaxPayments {
+		return nil, ErrTooManyPayments
+	}
+	if opts.DaysPerYear < 0 {
+		return nil, ErrInvalidDaysPerYear
+	}
+
+	sorted := normalize(payments, opts)
+	if err := validatePayments(sorted); err != nil {
+		return nil, err
+	}
+	if opts.MidPeriodFlows {
+		midPeriod(sorted)
+	}
+	if sorted[len(sorted)-1].Date.Sub(sorted[0].Date) < opts.MinHoldingPeriod {
+		return nil, ErrHoldingTooShort
+	}
+	return sorted, nil
+}
+
+// normalize returns a copy of payments sorted by date, with monotonic clock
+// readings stripped from the dates and with payments on the same day combined
+// when opts.AggregateSameDay is set. It is the part of Canonical that applies
+// to payments that need not form a solvable series, like valuations.
+func normalize(payments []Payment, opts Options) []Payment {
+	sorted := sortPayments(payments)
+	for i := range sorted {
+		sorted[i].Date = sorted[i].Date.Round(0)
+	}
+	if opts.AggregateSameDay {
+		sorted = aggregateSameDay(sorted)
+	}
+	return sorted
+}
+
+// aggregateSameDay combines payments sorted by date that are made on the same
+// calendar day, in place.
+func aggregateSameDay(sorted []Payment) []Payment {
+	result := sorted[:0]
+	for _, p := range sorted {
+		if n := len(result); n > 0 && sameDay(result[n-1].Date, p.Date) {
+			result[n-1].Amount += p.Amount
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// midPeriod moves the interior payments sorted by date to the midpoint of
+// their period, in place.
+func midPeriod(sorted []Payment) {
+	prev, cur := sorted[0].Date, sorted[0].Date
+	for i := 1; i < len(sorted)-1; i++ {
+		if date := sorted[i].Date; date.After(cur) {
+			prev, cur = cur, date
+		}
+		sorted[i].Date = prev.Add(cur.Sub(prev) / 2)
+	}
+}
+
+func sameDay(t1, t2 time.Time) bool {
+	y1, m1, d1 := t1.Date()
+	y2, m2, d2 := t2.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// solve calculates the internal rate of return of s.
+func solve(s series, opts Options) Result {
+	if len(s.amounts) == 2 && s.shifts == nil && opts.RootFinder == nil {
+		if exp := s.exps[1]; exp > 0 {
+			rate := math.Pow(-s.amounts[1]/s.amounts[0], 1/exp) - 1
+			res := Result{Rate: rate, Diagnostics: Diagnostics{Guess: math.NaN()}}
+			if opts.RecordHistory {
+				res.Diagnostics.IterationHistory = []float64{rate}
+			}
+			return res
+		}
+	}
+
+	if opts.CoarseTolerance > opts.withDefaults().Tolerance && s.shifts == nil {
+		// Binning payments that span less than a bin can leave too few, or
+		// only one sign, for the coarse series to have a rate.
+		if coarse := s.binned(coarseBin); coarse.hasBothSigns() {
+			s.coarse = &coarse
+		}
+	}
+
+	t := trace{record: opts.RecordHistory}
+	guess := 0.1
+	rate := computeWithGuess(s, guess, opts, &t)
+	for g := -0.99; g < 1.0 && (math.IsNaN(rate) || math.IsInf(rate, 0)); g += 0.01 {
+		guess = g
+		rate = computeWithGuess(s, guess, opts, &t)
+	}
+	if math.IsNaN(rate) || math.IsInf(rate, 0) {
+		guess, t.history = math.NaN(), nil
 	}
 
+	return Result{
+		Rate:        rate,
+		Iterations:  t.iterations,
+		Diagnostics: Diagnostics{Guess: guess, IterationHistory: t.history},
+	}
+}
+
+// Offsets returns the offset in years of each payment from the earliest one,
+// as used by Compute, in the order of payments sorted by date.
+func Offsets(payments []Payment) ([]float64, error) {
+	return OffsetsWithOptions(payments, Options{})
+}
+
+// OffsetsWithOptions returns the offset in years of each payment from the
+// earliest one like Offsets, as used by ComputeWithOptions with opts. The
+// payments are those returned by Canonical with opts.
+func OffsetsWithOptions(payments []Payment, opts Options) ([]float64, error) {
+	sorted, err := Canonical(payments, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newSeriesWithOptions(sorted, opts).exps, nil
+}
+
+// series holds the amounts of payments sorted by date, along with their
+// offsets in years from the first payment.
+type series struct {
+	amounts []float64
+	exps    []float64
+
+	// shifts, when not nil, holds an adjustment added to the rate at which
+	// each payment is discounted.
+	shifts []float64
+
+	// coarse, when not nil, is an approximation of the series with fewer
+	// payments, solved first when CoarseTolerance is set.
+	coarse *series
+}
+
+func newSeries(sorted []Payment) series {
+	return newSeriesWithOptions(sorted, Options{})
+}
+
+// newSeriesWithOptions returns the series of payments sorted by date, with
+// the time between them measured as configured by opts.
+func newSeriesWithOptions(sorted []Payment, opts Options) series {
+	s := series{amounts: make([]float64, len(sorted)), exps: make([]float64, len(sorted))}
+	for i, p := range sorted {
+		s.amounts[i] = p.Amount
+		s.exps[i] = yearFraction(p, sorted[0], opts)
+	}
+	return s
+}
+
+// rate returns the rate at which payment i is discounted when solving for
+// rate.
+func (s series) rate(i int, rate float64) float64 {
+	if s.shifts == nil {
+		return rate
+	}
+	return rate + s.shifts[i]
+}
+
+// binned returns an approximation of s, without shifts, with the payments in
+// each span of width years from the first combined into one, made at the
+// average time of those payments.
+func (s series) binned(width float64) series {
+	var b series
+	count := 0
+	for i, amount := range s.amounts {
+		n := len(b.amounts)
+		if n > 0 && math.Floor(s.exps[i]/width) == math.Floor(b.exps[n-1]/width) {
+			count++
+			b.amounts[n-1] += amount
+			b.exps[n-1] += (s.exps[i] - b.exps[n-1]) / float64(count)
+			continue
+		}
+		count = 1
+		b.amounts = append(b.amounts, amount)
+		b.exps = append(b.exps, s.exps[i])
+	}
+	return b
+}
+
+// hasBothSigns returns whether s has both positive and negative amounts.
+func (s series) hasBothSigns() bool {
+	positive, negative := false, false
+	for _, amount := range s.amounts {
+		positive = positive || amount > 0
+		negative = negative || amount < 0
+	}
+	return positive && negative
+}
+
+// sortPayments returns a copy of payments sorted by date, keeping payments
+// on the same date in their original order.
+func sortPayments(payments []Payment) []Payment {
 	sorted := make([]Payment, len(payments))
 	copy(sorted, payments)
-	sort.Slice(sorted, func(i, j int) bool {
+	sort.SliceStable(sorted, func(i, j int) bool {
 		return sorted[i].Date.Before(sorted[j].Date)
 	})
-
-	rate := computeWithGuess(sorted, 0.1)
-	for guess := -0.99; guess < 1.0 && (math.IsNaN(rate) || math.IsInf(rate, 0)); guess += 0.01 {
-		rate = computeWithGuess(sorted, guess)
-	}
-
-	return rate, nil
+	return sorted
 }
 
 func validatePayments(payments []Payment) error {
@@ -71,39 +511,159 @@ func validatePayments(payments []Payment) error {
 	return nil
 }
 
-func computeWithGuess(payments []Payment, guess float64) float64 {
+// trace tracks the progress of Newton's method across guesses.
+type trace struct {
+	record     bool
+	iterations int
+	history    []float64
+}
+
+func computeWithGuess(s series, guess float64, opts Options, t *trace) float64 {
+	if opts.RootFinder != nil {
+		f := func(r float64) float64 { return xirr(s, r, opts) }
+		df := func(r float64) float64 { return dxirr(s, r, opts) }
+		if r, ok := opts.RootFinder(f, df, guess); ok {
+			return r
+		}
+		return math.NaN()
+	}
+
+	if t.record {
+		t.history = append(t.history[:0], guess)
+	}
+
+	tolerance := opts.withDefaults().Tolerance
+	if opts.CoarseTolerance <= tolerance {
+		r, _ := newton(s, guess, tolerance, maxIter, opts, t)
+		return r
+	}
+
+	coarse := s
+	if s.coarse != nil {
+		coarse = *s.coarse
+	}
+	r, n := newton(coarse, guess, opts.CoarseTolerance, maxIter, opts, t)
+	if math.IsNaN(r) {
+		return r
+	}
+	r, _ = newton(s, r, tolerance, maxIter-n, opts, t)
+	return r
+}
+
+// newton finds the rate from guess using Newton's method, within at most
+// budget iterations. It returns the rate, or NaN if not found, along with
+// the number of iterations performed.
+func newton(s series, guess, tolerance float64, budget int, opts Options, t *trace) (float64, int) {
 	r, e := guess, 1.0
-	for i := 0; i < maxIter; i++ {
-		r1 := r - xirr(payments, r)/dxirr(payments, r)
+	for i := 0; i < budget; i++ {
+		r1 := r - xirr(s, r, opts)/dxirr(s, r, opts)
+		clamped := r1 <= -1.0
+		if clamped {
+			// A rate at or below -1 makes the discount factors undefined,
+			// so step halfway towards -1 instead. The shortened steps shrink
+			// regardless of whether there is a root, so they do not count
+			// towards convergence.
+			r1 = (r - 1.0) / 2
+		}
 		e = math.Abs(r1 - r)
 		r = r1
 
-		if e <= maxError {
-			return r
+		t.iterations++
+		if t.record {
+			t.history = append(t.history, r)
+		}
+		if e <= tolerance && !clamped {
+			return r, i + 1
 		}
 	}
 
-	return math.NaN()
+	return math.NaN(), budget
 }
 
-func xirr(payments []Payment, rate float64) float64 {
-	result := 0.0
-	for _, p := range payments {
-		exp := getExp(p, payments[0])
-		result += p.Amount / math.Pow(1.0+rate, exp)
+func xirr(s series, rate float64, opts Options) float64 {
+	result := sum{compensated: opts.CompensatedSum}
+	for i, amount := range s.amounts {
+		result.add(amount / math.Pow(1.0+s.rate(i, rate), s.exps[i]))
 	}
-	return result
+	return result.total
 }
 
-func dxirr(payments []Payment, rate float64) float64 {
-	result := 0.0
-	for _, p := range payments {
-		exp := getExp(p, payments[0])
-		result -= p.Amount * exp / math.Pow(1.0+rate, exp+1.0)
+func dxirr(s series, rate float64, opts Options) float64 {
+	result := sum{compensated: opts.CompensatedSum}
+	for i, amount := range s.amounts {
+		exp := s.exps[i]
+		result.add(-amount * exp / math.Pow(1.0+s.rate(i, rate), exp+1.0))
 	}
-	return result
+	return result.total
+}
+
+// sum accumulates a total, optionally using Kahan summation to compensate
+// for the low-order bits lost in each addition.
+type sum struct {
+	compensated bool
+	total, c    float64
+}
+
+func (s *sum) add(x float64) {
+	if !s.compensated {
+		s.total += x
+		return
+	}
+
+	y := x - s.c
+	t := s.total + y
+	s.c = (t - s.total) - y
+	s.total = t
 }
 
+// getExp returns the number of years from p0 to p, counting whole days
+// between their wall clock times in the time zone of p0. The wall clock times
+// are compared as if they were in UTC, so that DST transitions in between do
+// not shift the number of days, while dates given in different time zones
+// are compared at the same instant.
 func getExp(p, p0 Payment) float64 {
-	return float64(p.Date.Sub(p0.Date)/(24*time.Hour)) / 365
+	return yearFraction(p, p0, Options{})
+}
+
+// yearFraction returns the years from p0 to p by the day count and rounding
+// of opts.
+func yearFraction(p, p0 Payment, opts Options) float64 {
+	yearDays, _ := opts.yearDays()
+	date := p.Date.In(p0.Date.Location())
+	if opts.DayCount == Thirty360 {
+		return thirty360Days(p0.Date, date) / yearDays
+	}
+
+	d := wallClock(date).Sub(wallClock(p0.Date))
+	switch opts.DayRounding {
+	case DayRound:
+		d = d.Round(24 * time.Hour)
+	case DayCeil:
+		if t := d.Truncate(24 * time.Hour); d > t {
+			d = t + 24*time.Hour
+		}
+	case DayExact:
+		return d.Hours() / 24 / yearDays
+	}
+	return float64(d/(24*time.Hour)) / yearDays
+}
+
+// thirty360Days returns the days from t1 to t2 by the 30/360 US bond basis.
+func thirty360Days(t1, t2 time.Time) float64 {
+	y1, m1, d1 := t1.Date()
+	y2, m2, d2 := t2.Date()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+	return float64(360*(y2-y1) + 30*(int(m2)-int(m1)) + d2 - d1)
+}
+
+// wallClock returns the time in UTC with the same wall clock reading as t.
+func wallClock(t time.Time) time.Time {
+	y, m, d := t.Date()
+	hour, min, sec := t.Clock()
+	return time.Date(y, m, d, hour, min, sec, t.Nanosecond(), time.UTC)
 }
@@ -6,6 +6,7 @@ package xirr
 
 import (
 	"encoding/csv"
+	"fmt"
 	"io"
 	"math"
 	"os"
@@ -42,6 +43,51 @@ func TestSamples(t *testing.T) {
 	}
 }
 
+func TestRootFinder(t *testing.T) {
+	calls := 0
+	bisect := func(f, df func(float64) float64, guess float64) (float64, bool) {
+		calls++
+		lo, hi := 0.0, 1.0
+		if f(lo)*f(hi) > 0 {
+			return 0, false
+		}
+		for hi-lo > 1e-12 {
+			mid := (lo + hi) / 2
+			if f(lo)*f(mid) <= 0 {
+				hi = mid
+			} else {
+				lo = mid
+			}
+		}
+		return (lo + hi) / 2, true
+	}
+
+	for _, c := range []struct {
+		file string
+		rate float64
+	}{
+		{"single_redemption.csv", 0.1361695793742},
+		{"random.csv", 0.6924974337277},
+	} {
+		payments, err := loadPayments(c.file)
+		if err != nil {
+			t.Fatal("Error loading input:", err)
+		}
+
+		rate, err := ComputeWithOptions(payments, Options{RootFinder: bisect})
+		if err != nil {
+			t.Fatal("Error computing XIRR:", err)
+		}
+		if math.Abs(rate-c.rate) >= maxError {
+			t.Errorf("Expected %.10f, but was %.10f", c.rate, rate)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected 2 calls to the root finder, but was %d", calls)
+	}
+}
+
 func TestSameSign(t *testing.T) {
 	_, err := Compute([]Payment{
 		{parseDate("2016-06-11"), -100},
@@ -60,6 +106,353 @@ func TestSameSign(t *testing.T) {
 	}
 }
 
+func TestShortPosition(t *testing.T) {
+	rate, err := Compute([]Payment{
+		{parseDate("2017-06-11"), 100},
+		{parseDate("2018-06-11"), -130},
+	})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(rate-0.3) >= maxError {
+		t.Fatalf("Expected %.10f, but was %.10f", 0.3, rate)
+	}
+}
+
+func TestCompensatedSum(t *testing.T) {
+	// The unit payments are lost to rounding when naively added to the
+	// large payment preceding them, while the rate is determined by the last
+	// two payments.
+	const n = 20000
+	start := parseDate("2016-06-11")
+	payments := []Payment{{start, 1e16}}
+	for i := 1; i <= n; i++ {
+		payments = append(payments, Payment{start.Add(time.Duration(i) * time.Second), 1})
+	}
+	payments = append(payments,
+		Payment{start.Add((n + 1) * time.Second), -(1e16 + n)},
+		Payment{start.Add((n + 2) * time.Second), -100},
+		Payment{parseDate("2017-06-11"), 110},
+	)
+
+	rate, err := ComputeWithOptions(payments, Options{CompensatedSum: true})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(rate-0.1) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", 0.1, rate)
+	}
+
+	rate, err = Compute(payments)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(rate-0.1) < maxError {
+		t.Errorf("Expected naive summation to drift from %.10f", 0.1)
+	}
+}
+
+func TestRateDomain(t *testing.T) {
+	// From the initial guess, the first Newton step falls below -1, where
+	// the fractional exponent of the second payment is undefined.
+	payments := []Payment{
+		{parseDate("2016-06-11"), -100},
+		{parseDate("2016-12-10"), 1},
+	}
+
+	rate := computeWithGuess(newSeries(payments), 0.1, Options{}, new(trace))
+	expected := math.Pow(0.01, 1/getExp(payments[1], payments[0])) - 1
+	if math.Abs(rate-expected) >= maxError {
+		t.Fatalf("Expected %.10f, but was %.10f", expected, rate)
+	}
+}
+
+func TestRateDomainNoRoot(t *testing.T) {
+	// The payments cancel out to a constant net present value, so Newton
+	// steps keep falling below -1 without there being a rate.
+	rate, err := Compute([]Payment{
+		{parseDate("2020-01-01"), -100},
+		{parseDate("2020-01-01"), 110},
+	})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if !math.IsNaN(rate) {
+		t.Errorf("Expected %.10f, but was %.10f", math.NaN(), rate)
+	}
+}
+
+func TestOffsets(t *testing.T) {
+	offsets, err := Offsets([]Payment{
+		{parseDate("2017-06-11"), 50},
+		{parseDate("2016-06-11"), -100},
+		{parseDate("2016-06-12"), -100},
+		{parseDate("2018-06-11"), 200},
+	})
+	if err != nil {
+		t.Fatal("Error computing offsets:", err)
+	}
+
+	expected := []float64{0, 1.0 / 365, 365.0 / 365, 730.0 / 365}
+	for i, o := range offsets {
+		if o != expected[i] {
+			t.Errorf("Expected offset %d to be %.10f, but was %.10f", i, expected[i], o)
+		}
+	}
+}
+
+func TestOffsetsWithOptions(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2017-06-11"), 50},
+		{parseDate("2016-06-11"), -100},
+		{parseDate("2016-06-12"), -100},
+		{parseDate("2018-06-11"), 200},
+	}
+
+	for _, c := range []struct {
+		opts     Options
+		expected []float64
+	}{
+		{Options{DayCount: Actual360}, []float64{0, 1.0 / 360, 365.0 / 360, 730.0 / 360}},
+		{Options{DayCount: Thirty360}, []float64{0, 1.0 / 360, 1, 2}},
+		{Options{DaysPerYear: 250}, []float64{0, 1.0 / 250, 365.0 / 250, 730.0 / 250}},
+	} {
+		offsets, err := OffsetsWithOptions(payments, c.opts)
+		if err != nil {
+			t.Fatal("Error computing offsets:", err)
+		}
+		for i, o := range offsets {
+			if o != c.expected[i] {
+				t.Errorf("Expected offset %d to be %.10f with %+v, but was %.10f", i, c.expected[i], c.opts, o)
+			}
+		}
+	}
+}
+
+func TestMaxPayments(t *testing.T) {
+	payments, err := loadPayments("random.csv")
+	if err != nil {
+		t.Fatal("Error loading input:", err)
+	}
+
+	_, err = ComputeWithOptions(payments, Options{MaxPayments: len(payments) - 1})
+	if err != ErrTooManyPayments {
+		t.Errorf("Invalid error above the limit: %v", err)
+	}
+
+	_, err = ComputeWithOptions(payments, Options{MaxPayments: len(payments)})
+	if err != nil {
+		t.Errorf("Error computing XIRR at the limit: %v", err)
+	}
+}
+
+func TestTwoPayments(t *testing.T) {
+	cases := [][]Payment{
+		{{parseDate("2016-06-11"), -78789.58}, {parseDate("2021-03-02"), 160432.97}},
+		{{parseDate("2016-06-11"), 100}, {parseDate("2016-08-01"), -103}},
+		{{parseDate("2016-06-11"), -1000}, {parseDate("2019-12-31"), 640}},
+	}
+
+	for _, payments := range cases {
+		rate, err := Compute(payments)
+		if err != nil {
+			t.Fatal("Error computing XIRR:", err)
+		}
+
+		iterative := computeWithGuess(newSeries(payments), 0.1, Options{Tolerance: 1e-15}, new(trace))
+		if math.Abs(rate-iterative) > 1e-14 {
+			t.Errorf("Expected %.16f, but was %.16f", iterative, rate)
+		}
+	}
+}
+
+func TestMinHoldingPeriod(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2016-06-11"), -100},
+		{parseDate("2016-06-13"), 101},
+	}
+
+	_, err := ComputeWithOptions(payments, Options{MinHoldingPeriod: 30 * 24 * time.Hour})
+	if err != ErrHoldingTooShort {
+		t.Errorf("Invalid error for short holding period: %v", err)
+	}
+
+	_, err = ComputeWithOptions(payments, Options{MinHoldingPeriod: 2 * 24 * time.Hour})
+	if err != nil {
+		t.Errorf("Error computing XIRR: %v", err)
+	}
+}
+
+func TestCoarseTolerance(t *testing.T) {
+	// All payments of short fall in a single bin, so binning them leaves a
+	// series without a rate.
+	short := []Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2020-01-11"), 500},
+		{parseDate("2020-01-31"), 510},
+	}
+	for _, file := range []string{"", "single_redemption.csv", "random.csv"} {
+		payments := short
+		if file != "" {
+			var err error
+			if payments, err = loadPayments(file); err != nil {
+				t.Fatal("Error loading input:", err)
+			}
+		}
+
+		for _, tolerance := range []float64{0, 1e-12} {
+			single, err := ComputeVerbose(payments, Options{Tolerance: tolerance})
+			if err != nil {
+				t.Fatal("Error computing XIRR:", err)
+			}
+			coarse, err := ComputeVerbose(payments, Options{Tolerance: tolerance, CoarseTolerance: 1e-2})
+			if err != nil {
+				t.Fatal("Error computing XIRR:", err)
+			}
+			if !(math.Abs(coarse.Rate-single.Rate) < maxError) {
+				t.Errorf("Expected %.10f for %s, but was %.10f", single.Rate, file, coarse.Rate)
+			}
+		}
+	}
+}
+
+func BenchmarkCoarseTolerance(b *testing.B) {
+	for _, file := range []string{"single_redemption.csv", "random.csv"} {
+		payments, err := loadPayments(file)
+		if err != nil {
+			b.Fatal("Error loading input:", err)
+		}
+
+		for _, coarse := range []float64{0, 1e-2} {
+			b.Run(fmt.Sprint(file, "/", coarse), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					ComputeWithOptions(payments, Options{CoarseTolerance: coarse})
+				}
+			})
+		}
+	}
+}
+
+func TestRecordHistory(t *testing.T) {
+	payments, err := loadPayments("single_redemption.csv")
+	if err != nil {
+		t.Fatal("Error loading input:", err)
+	}
+
+	res, err := ComputeVerbose(payments, Options{RecordHistory: true})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+
+	history := res.Diagnostics.IterationHistory
+	if len(history) != res.Iterations+1 || history[0] != res.Diagnostics.Guess {
+		t.Fatalf("Expected %d estimates from %.10f, but was %v", res.Iterations+1, res.Diagnostics.Guess, history)
+	}
+	if history[len(history)-1] != res.Rate {
+		t.Errorf("Expected last estimate to be %.10f, but was %.10f", res.Rate, history[len(history)-1])
+	}
+	for i := 1; i < len(history); i++ {
+		if math.Abs(history[i]-res.Rate) > math.Abs(history[i-1]-res.Rate) {
+			t.Errorf("Estimate %d moved away from the rate: %v", i, history)
+		}
+	}
+
+	// The estimates are converted to the compounding basis like the rate.
+	res, err = ComputeVerbose(payments, Options{RecordHistory: true, DayCount: Actual360})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	history = res.Diagnostics.IterationHistory
+	if history[0] != res.Diagnostics.Guess {
+		t.Errorf("Expected first estimate to be %.10f, but was %.10f", res.Diagnostics.Guess, history[0])
+	}
+	if history[len(history)-1] != res.Rate {
+		t.Errorf("Expected last estimate to be %.10f, but was %.10f", res.Rate, history[len(history)-1])
+	}
+
+	res, _ = ComputeVerbose(payments, Options{})
+	if res.Diagnostics.IterationHistory != nil {
+		t.Errorf("Expected no history by default, but was %v", res.Diagnostics.IterationHistory)
+	}
+}
+
+func TestDSTOffsets(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("Time zone not available:", err)
+	}
+
+	// Clocks sprang forward on 2018-03-11, so only 47 hours separate the
+	// first two payments.
+	offsets, err := Offsets([]Payment{
+		{time.Date(2018, 3, 10, 0, 0, 0, 0, loc), -100},
+		{time.Date(2018, 3, 12, 0, 0, 0, 0, loc), -100},
+		{time.Date(2019, 3, 10, 0, 0, 0, 0, loc), 210},
+	})
+	if err != nil {
+		t.Fatal("Error computing offsets:", err)
+	}
+
+	expected := []float64{0, 2.0 / 365, 365.0 / 365}
+	for i, o := range offsets {
+		if o != expected[i] {
+			t.Errorf("Expected offset %d to be %.10f, but was %.10f", i, expected[i], o)
+		}
+	}
+}
+
+func TestMixedTimeZoneOffsets(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip("Time zone not available:", err)
+	}
+
+	// The second payment is 20:00 UTC, ten days after the first, but given
+	// as 12:00 in Los Angeles.
+	offsets, err := Offsets([]Payment{
+		{time.Date(2021, 1, 1, 20, 0, 0, 0, time.UTC), -100},
+		{time.Date(2021, 1, 11, 12, 0, 0, 0, loc), 101},
+	})
+	if err != nil {
+		t.Fatal("Error computing offsets:", err)
+	}
+	if expected := 10.0 / 365; offsets[1] != expected {
+		t.Errorf("Expected offset to be %.10f, but was %.10f", expected, offsets[1])
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	res, err := ComputeVerbose([]Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2016-06-11"), -1e-7},
+		{parseDate("2016-06-20"), 1010},
+	}, Options{})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.IsNaN(res.Rate) {
+		t.Error("Expected a rate despite warnings")
+	}
+
+	expected := []string{
+		"negligible payment of -1e-07 on 2016-06-11",
+		"multiple payments on 2016-06-11",
+		"short holding period of 216h0m0s",
+	}
+	if fmt.Sprint(res.Diagnostics.Warnings) != fmt.Sprint(expected) {
+		t.Errorf("Expected warnings %q, but was %q", expected, res.Diagnostics.Warnings)
+	}
+
+	res, _ = ComputeVerbose([]Payment{
+		{parseDate("2016-06-11"), -1000},
+		{parseDate("2016-12-10"), -500},
+		{parseDate("2017-06-11"), 1600},
+	}, Options{})
+	if len(res.Diagnostics.Warnings) != 0 {
+		t.Errorf("Expected no warnings, but was %q", res.Diagnostics.Warnings)
+	}
+}
+
 func TestMaxIter(t *testing.T) {
 	rate, err := Compute([]Payment{
 		{parseDate("2020-10-19"), -10000},
@@ -78,6 +471,216 @@ func TestMaxIter(t *testing.T) {
 	}
 }
 
+func TestCanonical(t *testing.T) {
+	now := time.Now()
+	payments := []Payment{
+		{now.AddDate(1, 0, 0), 1100},
+		{now, -1000},
+		{now.Add(time.Minute), -500},
+		{now.AddDate(0, 6, 0), 500},
+	}
+
+	sorted, err := Canonical(payments, Options{})
+	if err != nil {
+		t.Fatal("Error canonicalizing payments:", err)
+	}
+	if len(sorted) != len(payments) {
+		t.Fatalf("Expected %d payments, but was %d", len(payments), len(sorted))
+	}
+	for i, p := range sorted {
+		if p.Date != p.Date.Round(0) {
+			t.Errorf("Monotonic clock not stripped from payment %d", i)
+		}
+		if i > 0 && p.Date.Before(sorted[i-1].Date) {
+			t.Errorf("Payment %d out of order", i)
+		}
+	}
+
+	aggregated, err := Canonical([]Payment{
+		{parseDate("2021-01-01"), 1100},
+		{parseDate("2020-01-01").Add(time.Hour), -500},
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2020-07-01"), 500},
+	}, Options{AggregateSameDay: true})
+	if err != nil {
+		t.Fatal("Error canonicalizing payments:", err)
+	}
+	if len(aggregated) != 3 {
+		t.Fatalf("Expected 3 payments, but was %d", len(aggregated))
+	}
+	if aggregated[0].Amount != -1500 || !aggregated[0].Date.Equal(parseDate("2020-01-01")) {
+		t.Errorf("Expected -1500 on 2020-01-01, but was %v on %v",
+			aggregated[0].Amount, aggregated[0].Date)
+	}
+
+	if _, err := Canonical(payments[:1], Options{}); err != ErrInvalidPayments {
+		t.Errorf("Expected %v, but was %v", ErrInvalidPayments, err)
+	}
+}
+
+func TestMidPeriodFlows(t *testing.T) {
+	payments := []Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2020-04-01"), 30},
+		{parseDate("2020-07-01"), 30},
+		{parseDate("2020-10-01"), 30},
+		{parseDate("2021-01-01"), 1000},
+	}
+
+	exact, err := Compute(payments)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	mid, err := ComputeWithOptions(payments, Options{MidPeriodFlows: true})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if mid <= exact {
+		t.Errorf("Expected mid-period rate above %.10f, but was %.10f", exact, mid)
+	}
+
+	shifted, err := Compute([]Payment{
+		{parseDate("2020-01-01"), -1000},
+		{parseDate("2020-02-15").Add(12 * time.Hour), 30},
+		{parseDate("2020-05-16").Add(12 * time.Hour), 30},
+		{parseDate("2020-08-16"), 30},
+		{parseDate("2021-01-01"), 1000},
+	})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	if math.Abs(mid-shifted) > maxError {
+		t.Errorf("Expected %.10f, but was %.10f", shifted, mid)
+	}
+}
+
+func TestDayRounding(t *testing.T) {
+	start := parseDate("2020-01-01")
+	payments := []Payment{
+		{start, -1000},
+		{start.Add(36 * time.Hour), 1001},
+	}
+
+	for _, c := range []struct {
+		rounding DayRounding
+		days     float64
+	}{
+		{DayFloor, 1},
+		{DayRound, 2},
+		{DayCeil, 2},
+		{DayExact, 1.5},
+	} {
+		offsets := newSeriesWithOptions(payments, Options{DayRounding: c.rounding}).exps
+		if offsets[1] != c.days/365 {
+			t.Errorf("Expected offset of %g days for %d, but was %g", c.days, c.rounding, offsets[1]*365)
+		}
+
+		rate, err := ComputeWithOptions(payments, Options{DayRounding: c.rounding})
+		if err != nil {
+			t.Fatal("Error computing XIRR:", err)
+		}
+		expected := math.Pow(1.001, 365/c.days) - 1
+		if math.Abs(rate-expected) > maxError*expected {
+			t.Errorf("Expected %.10f for %d, but was %.10f", expected, c.rounding, rate)
+		}
+	}
+}
+
+func TestCompoundingBasis(t *testing.T) {
+	payments, err := loadPayments("random.csv")
+	if err != nil {
+		t.Fatal("Error loading input:", err)
+	}
+	rate, err := Compute(payments)
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+
+	// Measuring time in years of 360 days, the 365-day effective rate is
+	// unchanged, while the 360-day one is the equivalent over 360 days.
+	for _, c := range []struct {
+		basis    DayCount
+		expected float64
+	}{
+		{Actual365, rate},
+		{Actual360, math.Pow(1+rate, 360.0/365) - 1},
+	} {
+		actual, err := ComputeWithOptions(payments, Options{DayCount: Actual360, CompoundingBasis: c.basis})
+		if err != nil {
+			t.Fatal("Error computing XIRR:", err)
+		}
+		if math.Abs(actual-c.expected) >= maxError {
+			t.Errorf("Expected %.10f for basis %d, but was %.10f", c.expected, c.basis, actual)
+		}
+	}
+
+	// A year by 30/360 from the end of January is 360 days, over which
+	// 1000 grows to 1100.
+	actual, err := ComputeWithOptions([]Payment{
+		{parseDate("2021-01-31"), -1000},
+		{parseDate("2022-01-31"), 1100},
+	}, Options{DayCount: Thirty360})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	expected := math.Pow(1.1, 365.0/360) - 1
+	if math.Abs(actual-expected) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, actual)
+	}
+}
+
+func TestThirty360Days(t *testing.T) {
+	for _, c := range []struct {
+		from, to string
+		days     float64
+	}{
+		{"2021-01-31", "2021-03-01", 31},
+		{"2021-01-30", "2021-03-31", 60},
+		{"2021-02-28", "2021-03-31", 33},
+		{"2020-01-15", "2021-01-15", 360},
+	} {
+		if days := thirty360Days(parseDate(c.from), parseDate(c.to)); days != c.days {
+			t.Errorf("Expected %g days from %s to %s, but was %g", c.days, c.from, c.to, days)
+		}
+	}
+}
+
+func TestDaysPerYear(t *testing.T) {
+	payments, err := loadPayments("random.csv")
+	if err != nil {
+		t.Fatal("Error loading input:", err)
+	}
+	sorted := sortPayments(payments)
+
+	exps := newSeriesWithOptions(sorted, Options{DaysPerYear: 365}).exps
+	tradingExps := newSeriesWithOptions(sorted, Options{DaysPerYear: 250}).exps
+	for i := 1; i < len(exps); i++ {
+		if exps[i] == 0 {
+			continue
+		}
+		if ratio := tradingExps[i] / exps[i]; math.Abs(ratio-365.0/250) > 1e-12 {
+			t.Errorf("Expected exponent ratio %g, but was %g", 365.0/250, ratio)
+		}
+	}
+
+	rate, err := ComputeWithOptions(payments, Options{DaysPerYear: 365})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	tradingRate, err := ComputeWithOptions(payments, Options{DaysPerYear: 250})
+	if err != nil {
+		t.Fatal("Error computing XIRR:", err)
+	}
+	expected := math.Pow(1+rate, 250.0/365) - 1
+	if math.Abs(tradingRate-expected) >= maxError {
+		t.Errorf("Expected %.10f, but was %.10f", expected, tradingRate)
+	}
+
+	if _, err := ComputeWithOptions(payments, Options{DaysPerYear: -1}); err != ErrInvalidDaysPerYear {
+		t.Errorf("Expected %v, but was %v", ErrInvalidDaysPerYear, err)
+	}
+}
+
 func loadPayments(file string) ([]Payment, error) {
 	f, err := os.Open("samples/" + file)
 	if err != nil {